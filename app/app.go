@@ -0,0 +1,423 @@
+// app.go
+package app
+
+// App runs the whole application lifecycle: SDL and font setup, the window
+// and renderer, the event/update/render loop and shutdown. Programs fill in
+// the hooks instead of writing their own main loop.
+
+import (
+	"log/slog"
+	"net"
+	"sync/atomic"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+
+	"arkenidar.com/purego-sdl3/audio"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// How long an idle loop sleeps between checks for background work (milliseconds)
+const idleWaitMS = 100
+
+// App owns the window, renderer and main loop
+type App struct {
+	Config    Config
+	Window    *sdl.Window
+	Renderer  *sdl.Renderer
+	Font      *ttf.Font        // Default UI font (Config.FontPath), also the fallback of Fonts
+	Assets    *ui.AssetManager // Fonts, images and sounds from Config.Assets
+	Fonts     *ui.FontManager  // Follows display scale changes and finishes background loads
+	Width     float32          // Window size in logical units
+	Height    float32
+	Clock     *FrameClock
+	Scenes    *SceneManager         // Screens of the application, run between the hooks
+	Shortcuts *ShortcutManager      // Keyboard shortcuts, dispatched before OnEvent and the scenes
+	Focus     *ui.FocusManager      // Keyboard focus, gets events after the shortcuts (set its Roots)
+	Cursors   *ui.CursorManager     // Mouse cursor for the widget under the mouse (set its Roots)
+	Gamepads  *Gamepads             // Connected game controllers and their state
+	Gestures  *ui.GestureRecognizer // Taps, long-presses and swipes on touch screens (set its Roots)
+	Drag      *ui.DragDropManager   // Drag and drop between widgets, before the shortcuts (set its Roots)
+	Pens      *ui.PenTracker        // Pen and stylus pressure and tilt for PenReceiver widgets (set its Roots)
+	Toasts    *ui.Toaster           // Messages drawn over everything, e.g. Notify without system notifications
+	Audio     *audio.Device         // Sound output (see PlaySound), plays nothing without a device
+	Alpha     float32               // With a fixed timestep: how far rendering is between the last two steps (0 to 1)
+	Settings  *Settings             // Loaded before OnInit and saved after OnQuit (nil without Config.AppName)
+
+	// Feedback sounds by theme (see ui.PlaySound), replace or delete them to change the sounds
+	ThemeSounds map[Theme]UISounds
+
+	OnInit        func()                       // Window, renderer and fonts are ready, create widgets here
+	OnEvent       func(event sdl.Event)        // Every event, after the App's own handling
+	OnUpdate      func(dt float32)             // Once per loop iteration, dt in seconds
+	OnFixedUpdate func(step float32)           // Zero or more times per iteration with Config.FixedTimestep
+	OnRender      func(renderer *sdl.Renderer) // Draws a frame over the scenes (the App presents it)
+	OnQuit        func()                       // Before anything is destroyed
+
+	OnFilesDropped func(paths []string, x, y float32) // Files dragged onto the window from a file manager, at x, y
+	OnTextDropped  func(text string, x, y float32)    // Text dragged onto the window from another application
+
+	OnClipboardChanged func()            // This or another application changed the clipboard (see ui.GetClipboardText)
+	OnDisplaysChanged  func()            // Displays were added, removed, moved or changed mode or scale (see Displays), relayout here
+	OnLocaleChanged    func()            // The user changed their preferred languages (see PreferredLocales)
+	OnThemeChanged     func(theme Theme) // Switched between light and dark (SetTheme or the system)
+
+	// Another copy was started with Config.SingleInstance and handed over its
+	// command line (without the program name) and working directory
+	OnSecondInstance func(args []string, workingDir string)
+
+	running        bool
+	needsRedraw    bool
+	animating      bool    // Ticks, scenes or fixed steps want the next frame right away, don't wait for events
+	updating       bool    // Inside the update hooks, Redraw then means the next frame is due right away
+	stalled        bool    // Warned that frame time didn't advance while animating
+	accumulator    float32 // Frame time not yet consumed by fixed steps
+	mainThread     mainThreadQueue
+	wakeEvent      atomic.Uint32              // User event type waking the loop for mainThread (0 before Run)
+	tasks          map[*Task]struct{}         // Background tasks not finished yet
+	ticks          []*tickCallback            // See OnTick
+	timers         []*Timer                   // See After and Every
+	windowGeometry sdl.Rect                   // Last size and position while not maximized (Config.RememberWindow)
+	droppedFiles   []string                   // Files of the drop in progress
+	hotkeys        map[Shortcut]*globalHotkey // See RegisterGlobalHotkey
+	nextHotkeyID   int32
+	relativeMouse  bool                                 // See SetRelativeMouse
+	dialogs        map[Scene]struct{}                   // Opened with OpenDialog, suspend the relative mouse mode
+	theme          Theme                                // See SetTheme
+	hitTest        func(x, y float32) sdl.HitTestResult // See SetHitTest
+	hitTestSet     bool                                 // The SDL callback is installed
+	instance       net.Listener                         // Receives the command lines of later copies (Config.SingleInstance)
+	sounds         map[string]*audio.Sound              // Decoded sound assets (see PlaySound)
+}
+
+func New(config Config) *App {
+	a := &App{Config: config, tasks: make(map[*Task]struct{})}
+	a.Scenes = NewSceneManager(a)
+	a.Shortcuts = NewShortcutManager()
+	a.Shortcuts.Bind("F11", "Toggle fullscreen", a.ToggleFullscreen)
+	a.Focus = ui.NewFocusManager()
+	a.Cursors = ui.NewCursorManager()
+	a.Gamepads = NewGamepads()
+	a.Gestures = ui.NewGestureRecognizer(nil)
+	a.Drag = ui.NewDragDropManager()
+	a.Pens = ui.NewPenTracker()
+	a.Audio = audio.Silent() // Until Run opens the device
+	a.ThemeSounds = map[Theme]UISounds{ThemeLight: DefaultUISounds(ThemeLight), ThemeDark: DefaultUISounds(ThemeDark)}
+	return a
+}
+
+// Redraw requests a new frame. Events other than mouse motion request one by
+// themselves, call Redraw for motion that changed something and while animating.
+// Called from OnUpdate (or a tick), the loop runs the next frame without
+// waiting for events, so dt keeps advancing.
+func (a *App) Redraw() {
+	a.needsRedraw = true
+	if a.updating {
+		a.animating = true
+	}
+}
+
+// Quit ends the loop after the current iteration
+func (a *App) Quit() {
+	a.running = false
+}
+
+// Run initializes SDL, calls the hooks until Quit (or the window is closed) and shuts down
+func (a *App) Run() {
+	defer a.handleCrash() // Runs last, after everything was shut down
+	slog.SetLogLoggerLevel(a.Config.LogLevel)
+	if a.Config.SingleInstance {
+		if a.forwardToRunningInstance() {
+			return // The running copy takes over, no window opens
+		}
+		defer a.closeInstanceListener()
+	}
+
+	defer sdl.Quit()
+	sdl.SetHint(sdl.HintTouchMouseEvents, "1") // Fingers also click and drag like the mouse
+	if !sdl.Init(sdl.InitVideo) {
+		panic(sdl.GetError())
+	}
+	a.wakeEvent.Store(sdl.RegisterEvents(1)) // Lets RunOnMainThread wake the idle loop
+	defer a.wakeEvent.Store(0)
+	defer a.unregisterGlobalHotkeys()
+	if sdl.InitSubSystem(sdl.InitGamepad) {
+		defer a.Gamepads.Close() // Before the subsystem quits with SDL
+	} else {
+		slog.Warn("gamepads not available", "error", sdl.GetError())
+	}
+	if a.openAudio() {
+		defer a.closeAudio() // Before SDL quits
+	}
+	defer ttf.Quit()
+	if !ttf.Init() {
+		panic(sdl.GetError())
+	}
+
+	if a.Config.AppName != "" {
+		// A damaged file is replaced by the next save
+		var err error
+		a.Settings, err = LoadSettings(SettingsPath(a.Config.Organization, a.Config.AppName))
+		if err != nil {
+			slog.Warn("settings not loaded", "path", a.Settings.Path, "error", err)
+		}
+		defer func() {
+			if err := a.Settings.Save(); err != nil {
+				slog.Warn("settings not saved", "path", a.Settings.Path, "error", err)
+			}
+		}()
+		a.Audio.SetMuted(a.Settings.Bool("audio.muted", false))
+	}
+
+	uiScale := a.Config.UIScale
+	if uiScale <= 0 {
+		uiScale = 1
+	}
+	a.Assets = ui.NewAssetManager(a.Config.Assets...)
+	font, err := a.Assets.Font(a.Config.FontPath, a.Config.FontSize*uiScale)
+	if err != nil {
+		panic(err)
+	}
+	a.Font = font
+	a.Fonts = ui.NewFontManager(a.Font)
+	defer a.Fonts.Destroy()
+	a.Fonts.Register("ui", a.Font)
+
+	rememberWindow := a.Config.RememberWindow && a.Settings != nil
+	if rememberWindow {
+		a.loadWindowSize()
+	}
+	windowFlags := sdl.WindowResizable | sdl.WindowHighPixelDensity
+	windowFlags |= a.Config.windowFlags()
+	a.Window, a.Renderer = CreateWindowAndRenderer(a.Config, windowFlags)
+	defer sdl.DestroyRenderer(a.Renderer)
+	defer sdl.DestroyWindow(a.Window)
+	a.Assets.Renderer = a.Renderer
+	a.applySizeLimits()
+	a.KeepAwake(a.Config.KeepAwake)
+	slog.Debug("locales", "preferred", PreferredLocales())
+	a.followSystemTheme() // Before OnInit, widgets start with the right colors
+	for _, display := range Displays() {
+		slog.Debug("display", "name", display.Name, "size", [2]int32{display.Width, display.Height}, "refresh", display.RefreshRate, "scale", display.ContentScale)
+	}
+	if a.Config.Icon != "" {
+		if err := a.SetIcon(a.Config.Icon); err != nil {
+			slog.Warn("window icon not set", "error", err)
+		}
+	}
+	a.Shortcuts.Window = a.Window
+	a.Focus.Window = a.Window
+	a.Gestures.Window = a.Window
+	a.OnTick(a.Gestures.Tick)
+	a.Toasts = ui.NewToaster(a.Font)
+	a.OnTick(a.Toasts.Tick)
+	a.OnTick(a.updateAudio)
+	defer a.Cursors.Destroy()
+	defer a.Assets.Destroy() // Textures before the renderer, fonts before TTF_Quit
+	if rememberWindow {
+		a.restoreWindowGeometry()
+		defer a.saveWindowGeometry() // Before the window is destroyed, after OnQuit
+	}
+
+	// Pace frames with vsync where available, the frame limiter caps the rest
+	if a.Config.VSync != 0 {
+		ApplyVSync(a.Renderer, a.Config.VSync)
+	}
+	frameLimiter := NewFrameLimiter(a.Config.TargetFPS)
+	defer ui.ClearTextCache() // Cached text textures belong to the renderer
+
+	// Render text at the display's pixel density (before any widgets are created)
+	ui.ApplyDisplayScale(a.Window, a.Renderer, a.Fonts)
+	a.Width, a.Height = float32(a.Config.Width), float32(a.Config.Height)
+
+	if a.OnInit != nil {
+		a.OnInit()
+	}
+	if a.OnQuit != nil {
+		defer a.OnQuit()
+	}
+	defer a.Scenes.Clear() // Scenes exit before OnQuit
+	defer a.cancelTasks()
+
+	// Frames are only redrawn after something changed, an idle app sleeps
+	// in WaitEventTimeout instead of repainting the same scene
+	a.running = true
+	a.needsRedraw = true
+	a.Clock = NewFrameClock()
+	for a.running {
+		if !a.needsRedraw && !a.animating {
+			// Wake up for the next event or timer, or periodically to pick up background font loads
+			sdl.WaitEventTimeout(nil, a.idleWait())
+			a.Clock.Reset() // Time spent idle is not frame time
+		}
+		dt := a.Clock.Tick()
+		if a.animating && dt == 0 && !a.stalled {
+			slog.Warn("frame time did not advance while animating") // Movement and animations would stand still
+			a.stalled = true
+		}
+		a.animating = false // Set again below by whatever still moves
+
+		// Swap in fonts that finished loading in the background
+		if a.Fonts.Poll() {
+			a.needsRedraw = true
+		}
+
+		var event sdl.Event
+		for sdl.PollEvent(&event) {
+			if uint32(event.Type()) == a.wakeEvent.Load() {
+				continue // Only wakes the loop for RunOnMainThread
+			}
+			a.handleEvent(event)
+			mx, my := ui.EventPosition(event)
+			a.Cursors.Update(event, mx, my)
+			a.Gestures.Update(event, mx, my)
+			if a.Toasts.Update(event, mx, my) {
+				continue // Clicked a toast away
+			}
+			if a.Pens.Update(event, mx, my) {
+				continue // Drawn by a PenReceiver
+			}
+			if a.Drag.Update(event, mx, my) {
+				a.needsRedraw = true // The drag image follows the mouse
+				continue
+			}
+			if a.Shortcuts.HandleEvent(event) {
+				continue
+			}
+			if a.Focus.Update(event, mx, my) {
+				continue // Tab, or keys used by the focused widget
+			}
+			if a.OnEvent != nil {
+				a.OnEvent(event)
+			}
+			a.Scenes.Event(event)
+		}
+		if !a.running {
+			break
+		}
+
+		// Work handed over by goroutines and due timers (usually change what is shown)
+		if a.runMainThreadFuncs() {
+			a.needsRedraw = true
+		}
+		if a.runTimers() {
+			a.needsRedraw = true
+		}
+
+		a.updating = true
+		if a.OnUpdate != nil {
+			a.OnUpdate(dt)
+		}
+		if a.runTicks(dt) {
+			a.needsRedraw, a.animating = true, true
+		}
+		if a.Scenes.Update(dt) {
+			a.needsRedraw, a.animating = true, true
+		}
+		a.fixedUpdate(dt)
+		a.updating = false
+		a.applyRelativeMouse() // Dialogs may have opened or closed
+
+		if !a.needsRedraw {
+			continue // Nothing changed, the last presented frame is still valid
+		}
+		a.needsRedraw = false
+
+		a.Scenes.Render(a.Renderer)
+		if a.OnRender != nil {
+			a.OnRender(a.Renderer)
+		}
+		a.Toasts.Bounds = sdl.FRect{W: a.Width, H: a.Height}
+		a.Toasts.Render(a.Renderer)
+		a.Focus.Render(a.Renderer)
+		a.Drag.Render(a.Renderer)
+		sdl.RenderPresent(a.Renderer)
+		frameLimiter.Wait()
+	}
+}
+
+// Helper function running the fixed timestep steps due after dt seconds.
+// Logic then advances by the same step at any frame rate, rendering
+// interpolates between the last two steps with Alpha.
+func (a *App) fixedUpdate(dt float32) {
+	step := a.Config.FixedTimestep
+	fixedScene, hasFixedScene := a.Scenes.Current().(FixedUpdater)
+	if step <= 0 || (a.OnFixedUpdate == nil && !hasFixedScene) {
+		return
+	}
+	a.accumulator += dt
+	for a.accumulator >= step {
+		if a.OnFixedUpdate != nil {
+			a.OnFixedUpdate(step)
+		}
+		if hasFixedScene {
+			fixedScene.FixedUpdate(step)
+		}
+		a.accumulator -= step
+	}
+	a.Alpha = a.accumulator / step
+	a.needsRedraw, a.animating = true, true // Simulations keep running on real elapsed time, every frame shows new state
+}
+
+// Lerp interpolates between the previous and current value of fixed-step
+// state, e.g. Lerp(prevX, x, app.Alpha) when rendering
+func Lerp(previous, current, alpha float32) float32 {
+	return previous + (current-previous)*alpha
+}
+
+// Helper function handling the events every application needs
+func (a *App) handleEvent(event sdl.Event) {
+	// Mouse motion only counts when it changes something (the hooks call Redraw)
+	if event.Type() != sdl.EventMouseMotion {
+		a.needsRedraw = true
+	}
+
+	a.Gamepads.HandleEvent(event)
+
+	switch event.Type() {
+	case sdl.EventQuit:
+		a.Quit()
+	case sdl.EventWindowResized:
+		a.Width = float32(event.Window().Data1)
+		a.Height = float32(event.Window().Data2)
+		if a.Config.RememberWindow && a.Settings != nil {
+			a.trackWindowGeometry()
+		}
+	case sdl.EventWindowMoved:
+		if a.Config.RememberWindow && a.Settings != nil {
+			a.trackWindowGeometry()
+		}
+	case sdl.EventWindowDisplayScaleChanged:
+		// Moved to a display with a different scale: re-render text
+		ui.ApplyDisplayScale(a.Window, a.Renderer, a.Fonts)
+	case sdl.EventMouseButtonDown:
+		// Drags keep getting motion and the release when the mouse leaves the window
+		sdl.CaptureMouse(true)
+	case sdl.EventMouseButtonUp:
+		if sdl.GetMouseState(nil, nil) == 0 {
+			sdl.CaptureMouse(false) // Last button released
+		}
+	case sdl.EventDropBegin, sdl.EventDropFile, sdl.EventDropText, sdl.EventDropComplete:
+		a.handleDrop(event)
+	case sdl.EventDisplayOrientation, sdl.EventDisplayAdded, sdl.EventDisplayRemoved, sdl.EventDisplayMoved,
+		sdl.EventDisplayDesktopModeChanged, sdl.EventDisplayCurrentModeChanged, sdl.EventDisplayContentScaleChanged:
+		a.displaysChanged()
+	case sdl.EventSystemThemeChanged:
+		a.followSystemTheme()
+	case sdl.EventLocaleChanged:
+		if a.OnLocaleChanged != nil {
+			a.OnLocaleChanged()
+		}
+	case sdl.EventClipboardUpdate:
+		if a.OnClipboardChanged != nil {
+			a.OnClipboardChanged()
+		}
+	case sdl.EventRenderTargetsReset:
+		// Cached render targets lost their contents
+		ui.InvalidateRenderCaches()
+	case sdl.EventRenderDeviceReset:
+		// All texture contents were lost, rebuild them from their sources
+		ui.RecreateTextures()
+		ui.InvalidateRenderCaches()
+	}
+}
@@ -0,0 +1,109 @@
+// audio.go
+package app
+
+// Sound output: the App opens the default playback device with the audio
+// subsystem, plays sound assets by name, each decoded once, and streams
+// music assets.
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/audio"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Helper function opening the playback device, without one a.Audio stays
+// silent. Returns false if the audio subsystem is not available.
+func (a *App) openAudio() bool {
+	if !sdl.InitSubSystem(sdl.InitAudio) {
+		slog.Warn("audio not available", "error", sdl.GetError())
+		return false
+	}
+	device, err := audio.Open()
+	if err != nil {
+		slog.Warn("audio device not opened", "error", err)
+		return true
+	}
+	slog.Debug("audio", "driver", sdl.GetCurrentAudioDriver(), "rate", device.Spec.Freq, "channels", device.Spec.Channels)
+	a.Audio = device
+	ui.SoundPlayer = a.playUISound
+	return true
+}
+
+// Helper function closing the device before the audio subsystem quits
+func (a *App) closeAudio() {
+	ui.SoundPlayer = nil
+	a.Audio.Close()
+	a.Audio = audio.Silent()
+	clear(a.sounds)
+	sdl.QuitSubSystem(sdl.InitAudio)
+}
+
+// Helper function freeing finished voices every frame
+func (a *App) updateAudio(dt float32) bool {
+	a.Audio.Update()
+	return false // Playing sounds need no frames
+}
+
+// Sound returns the named WAV asset decoded, kept for the next calls
+func (a *App) Sound(name string) (*audio.Sound, error) {
+	if sound, ok := a.sounds[name]; ok {
+		return sound, nil
+	}
+	data, err := a.Assets.Sound(name)
+	if err != nil {
+		return nil, err
+	}
+	defer a.Assets.ReleaseSound(name) // The decoded copy is kept instead
+	sound, err := audio.LoadWAV(data)
+	if err != nil {
+		return nil, err
+	}
+	if a.sounds == nil {
+		a.sounds = make(map[string]*audio.Sound)
+	}
+	a.sounds[name] = sound
+	return sound, nil
+}
+
+// PlaySound plays the named WAV asset at its own volume (see Audio for the
+// master volume), returns nil if it could not be played
+func (a *App) PlaySound(name string) *audio.Voice {
+	sound, err := a.Sound(name)
+	if err != nil {
+		slog.Warn("sound not played", "name", name, "error", err)
+		return nil
+	}
+	return a.Audio.Play(sound, 1)
+}
+
+// OpenMusic opens the named WAV asset for streaming (see audio.Music), call it
+// after Run started (e.g. in OnInit). The music is closed with the device on quit.
+func (a *App) OpenMusic(name string) (*audio.Music, error) {
+	file, err := a.Assets.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := file.(io.ReadSeeker)
+	if !ok {
+		// Not seekable (e.g. packed in a zip), streamed from memory instead
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	music, err := a.Audio.OpenMusic(reader)
+	if err != nil {
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	return music, nil
+}
@@ -0,0 +1,113 @@
+// command.go
+package app
+
+// Undo/redo: user actions are Commands executed through a CommandStack,
+// which remembers them so they can be undone (Ctrl+Z) and redone (Ctrl+Y or
+// Ctrl+Shift+Z). The foundation for editors.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Command is an undoable action
+type Command interface {
+	Do()
+	Undo()
+}
+
+// FuncCommand builds a Command from two functions
+type FuncCommand struct {
+	Name     string // Shown in menus, e.g. "Move square"
+	DoFunc   func()
+	UndoFunc func()
+}
+
+func (c *FuncCommand) Do()   { c.DoFunc() }
+func (c *FuncCommand) Undo() { c.UndoFunc() }
+
+// CommandStack holds the undo and redo history
+type CommandStack struct {
+	Limit    int    // Most commands kept for undo, 0 for unlimited
+	OnChange func() // Called after Do, Undo, Redo and Clear (e.g. to refresh menu state)
+	done     []Command
+	undone   []Command
+}
+
+func NewCommandStack(limit int) *CommandStack {
+	return &CommandStack{Limit: limit}
+}
+
+// Do executes cmd and records it for undo, dropping the redo history
+func (s *CommandStack) Do(cmd Command) {
+	cmd.Do()
+	s.done = append(s.done, cmd)
+	if s.Limit > 0 && len(s.done) > s.Limit {
+		s.done = s.done[len(s.done)-s.Limit:]
+	}
+	s.undone = s.undone[:0]
+	s.changed()
+}
+
+// Undo reverts the last command, returns false if there is none
+func (s *CommandStack) Undo() bool {
+	if len(s.done) == 0 {
+		return false
+	}
+	cmd := s.done[len(s.done)-1]
+	s.done = s.done[:len(s.done)-1]
+	cmd.Undo()
+	s.undone = append(s.undone, cmd)
+	s.changed()
+	return true
+}
+
+// Redo executes the last undone command again, returns false if there is none
+func (s *CommandStack) Redo() bool {
+	if len(s.undone) == 0 {
+		return false
+	}
+	cmd := s.undone[len(s.undone)-1]
+	s.undone = s.undone[:len(s.undone)-1]
+	cmd.Do()
+	s.done = append(s.done, cmd)
+	s.changed()
+	return true
+}
+
+func (s *CommandStack) CanUndo() bool { return len(s.done) > 0 }
+func (s *CommandStack) CanRedo() bool { return len(s.undone) > 0 }
+
+// Clear forgets the whole history (e.g. after loading a document)
+func (s *CommandStack) Clear() {
+	s.done = nil
+	s.undone = nil
+	s.changed()
+}
+
+// HandleEvent undoes on Ctrl+Z and redoes on Ctrl+Y or Ctrl+Shift+Z,
+// returns true if the event was one of these shortcuts
+func (s *CommandStack) HandleEvent(event sdl.Event) bool {
+	if event.Type() != sdl.EventKeyDown || event.Key().Mod&sdl.KeymodCtrl == 0 {
+		return false
+	}
+	switch event.Key().Scancode {
+	case sdl.ScancodeZ:
+		if event.Key().Mod&sdl.KeymodShift != 0 {
+			s.Redo()
+		} else {
+			s.Undo()
+		}
+		return true
+	case sdl.ScancodeY:
+		s.Redo()
+		return true
+	}
+	return false
+}
+
+// Helper function notifying OnChange
+func (s *CommandStack) changed() {
+	if s.OnChange != nil {
+		s.OnChange()
+	}
+}
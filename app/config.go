@@ -0,0 +1,235 @@
+// config.go
+
+// Package app runs applications built on the ui package: window and renderer
+// setup, the main loop and frame pacing.
+package app
+
+// Application settings: window setup, rendering backend and frame pacing
+// (vsync and frame cap).
+
+import (
+	"flag"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/assets"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Config holds the settings used when creating the window and running the loop
+type Config struct {
+	Title     string
+	Width     int32
+	Height    int32
+	VSync     int32 // SDL_SetRenderVSync value: 0 off, 1 every vertical refresh, -1 adaptive
+	TargetFPS int   // Frame cap applied by sleeping, 0 for unlimited
+	Backend   RenderBackend
+	FontPath  string  // Default UI font, an asset name or a file path
+	FontSize  float32 // In logical units
+	Icon      string  // Window icon image (BMP, PNG or JPEG asset), "" keeps the system default
+
+	// Where App.Assets looks for assets, first match wins
+	Assets []fs.FS
+
+	Fullscreen bool       // Start in (borderless desktop) fullscreen
+	UIScale    float32    // Multiplies FontSize, widgets sized by their text grow with it (0 means 1)
+	LogLevel   slog.Level // Least severe messages logged with log/slog
+
+	// Window style for overlays and tool windows. Borderless and AlwaysOnTop
+	// can also be changed later (App.SetBorderless, App.SetAlwaysOnTop).
+	Borderless  bool // No title bar and frame
+	AlwaysOnTop bool // Stays above other windows
+	Transparent bool // Pixels cleared with alpha 0 show the desktop
+	Utility     bool // Tool window, not shown in the task bar
+
+	// Limits of resizing by the user (0 for none), e.g. the smallest size the
+	// widgets fit in. Aspect is a fixed width/height ratio.
+	MinWidth, MinHeight int32
+	MaxWidth, MaxHeight int32
+	Aspect              float32
+
+	// Use the light or dark theme of the system and switch with it (see App.Theme)
+	FollowSystemTheme bool
+
+	// Keep the display from blanking or sleeping, e.g. for media players and
+	// kiosks (see App.KeepAwake)
+	KeepAwake bool
+
+	// Name the settings are stored under in the user's preference directory
+	// (see App.Settings), no settings are kept when AppName is empty
+	Organization string
+	AppName      string
+
+	// Save the window size, position and maximized state in the settings on
+	// quit and restore them on startup
+	RememberWindow bool
+
+	// Run one copy per user: a later start hands its command line to the
+	// running one (see App.OnSecondInstance), which comes to the front. Needs AppName.
+	SingleInstance bool
+
+	// Seconds per OnFixedUpdate step (e.g. 1.0/60), 0 disables the fixed timestep
+	FixedTimestep float32
+}
+
+// RenderBackend selects the SDL render driver used for drawing
+type RenderBackend int
+
+const (
+	BackendRenderer RenderBackend = iota // SDL's default 2D renderer driver for the platform
+	BackendGPU                           // SDL's "gpu" render driver on SDL_GPU (Vulkan, Metal, Direct3D 12), runs ui.Shader custom shaders
+)
+
+// DefaultConfig returns the settings of the demo app
+func DefaultConfig() Config {
+	return Config{
+		Title:     "App built with Go and SDL3",
+		Width:     700,
+		Height:    500,
+		VSync:     1,
+		TargetFPS: 60, // Still caps the loop where vsync is unsupported
+		FontPath:  "OpenDyslexic-Regular.ttf",
+		Icon:      "icon.png",
+		Assets:    DefaultAssetSources(),
+		FontSize:  24,
+		UIScale:   1,
+		LogLevel:  slog.LevelInfo,
+
+		RememberWindow:    true,
+		FollowSystemTheme: true,
+	}
+}
+
+// DefaultAssetSources returns a VFS where loose files in the "assets"
+// directories of the working directory and next to the executable override
+// an "assets.zip" pack next to the executable, which overrides the assets
+// built into the program
+func DefaultAssetSources() []fs.FS {
+	vfs := ui.NewVFS()
+	vfs.Mount(assets.FS, "", 0)
+	if exe, err := os.Executable(); err == nil {
+		dir := filepath.Dir(exe)
+		vfs.MountZip(filepath.Join(dir, "assets.zip"), "", 10) // Optional
+		vfs.MountDir(filepath.Join(dir, "assets"), "", 20)
+	}
+	vfs.MountDir("assets", "", 30)
+	return []fs.FS{vfs}
+}
+
+// ParseFlags overrides the config with command-line flags (see -help), call
+// it before New. Other flags can be defined on flag.CommandLine beforehand.
+func (c *Config) ParseFlags() {
+	c.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+}
+
+// RegisterFlags defines the config flags on set, with the current values as defaults
+func (c *Config) RegisterFlags(set *flag.FlagSet) {
+	set.Func("width", "window width (instead of the remembered one)", c.sizeFlag(&c.Width))
+	set.Func("height", "window height (instead of the remembered one)", c.sizeFlag(&c.Height))
+	set.BoolVar(&c.Fullscreen, "fullscreen", c.Fullscreen, "start in fullscreen")
+	set.BoolVar(&c.Borderless, "borderless", c.Borderless, "window without title bar and frame")
+	set.BoolVar(&c.AlwaysOnTop, "on-top", c.AlwaysOnTop, "keep the window above other windows")
+	set.BoolVar(&c.KeepAwake, "keep-awake", c.KeepAwake, "keep the display from sleeping")
+	set.Func("vsync", "vsync: 0 off, 1 on, -1 adaptive", intFlag(&c.VSync))
+	set.StringVar(&c.FontPath, "font", c.FontPath, "UI font, an asset name or a file path")
+	set.Func("ui-scale", "UI text scale, e.g. 1.5", floatFlag(&c.UIScale))
+	set.TextVar(&c.LogLevel, "log-level", c.LogLevel, "log level: debug, info, warn or error")
+}
+
+// Helper function parsing a window size flag, an explicit size replaces the remembered geometry
+func (c *Config) sizeFlag(value *int32) func(string) error {
+	parse := intFlag(value)
+	return func(text string) error {
+		c.RememberWindow = false
+		return parse(text)
+	}
+}
+
+// Helper function parsing an int32 flag value
+func intFlag(value *int32) func(string) error {
+	return func(text string) error {
+		n, err := strconv.ParseInt(text, 10, 32)
+		*value = int32(n)
+		return err
+	}
+}
+
+// Helper function parsing a float32 flag value
+func floatFlag(value *float32) func(string) error {
+	return func(text string) error {
+		f, err := strconv.ParseFloat(text, 32)
+		*value = float32(f)
+		return err
+	}
+}
+
+// CreateWindowAndRenderer creates the window and a renderer for the configured backend.
+// The GPU backend falls back to the default driver when SDL was built without
+// the "gpu" render driver or it fails to start (ui.NewShader then reports
+// ErrShadersUnsupported).
+func CreateWindowAndRenderer(config Config, flags sdl.WindowFlags) (*sdl.Window, *sdl.Renderer) {
+	window := sdl.CreateWindow(config.Title, config.Width, config.Height, flags)
+	if window == nil {
+		panic(sdl.GetError())
+	}
+	var renderer *sdl.Renderer
+	if config.Backend == BackendGPU && renderDriverAvailable("gpu") {
+		renderer = sdl.CreateRenderer(window, "gpu")
+	}
+	if renderer == nil {
+		renderer = sdl.CreateRenderer(window, "")
+	}
+	if renderer == nil {
+		panic(sdl.GetError())
+	}
+	return window, renderer
+}
+
+// Helper function reporting whether SDL was built with the named render driver
+func renderDriverAvailable(name string) bool {
+	for i := range sdl.GetNumRenderDrivers() {
+		if sdl.GetRenderDriver(i) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyVSync enables vsync, falling back to regular vsync if adaptive is unsupported.
+// Returns false if vsync could not be enabled.
+func ApplyVSync(renderer *sdl.Renderer, vsync int32) bool {
+	if sdl.SetRenderVSync(renderer, vsync) {
+		return true
+	}
+	if vsync == -1 {
+		return sdl.SetRenderVSync(renderer, 1)
+	}
+	return false
+}
+
+// FrameLimiter sleeps between frames to keep the loop at a target frame rate
+type FrameLimiter struct {
+	TargetFPS  int
+	frameStart uint64 // Start of the current frame, in nanoseconds
+}
+
+func NewFrameLimiter(targetFPS int) *FrameLimiter {
+	return &FrameLimiter{TargetFPS: targetFPS, frameStart: sdl.GetTicksNS()}
+}
+
+// Wait sleeps for the rest of the frame budget, call it after presenting a frame
+func (l *FrameLimiter) Wait() {
+	if l.TargetFPS > 0 {
+		budget := uint64(1_000_000_000 / l.TargetFPS)
+		if elapsed := sdl.GetTicksNS() - l.frameStart; elapsed < budget {
+			sdl.DelayNS(budget - elapsed)
+		}
+	}
+	l.frameStart = sdl.GetTicksNS()
+}
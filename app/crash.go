@@ -0,0 +1,45 @@
+// crash.go
+package app
+
+// Crash handling: a panic in the loop or a hook (including the fatal SDL
+// errors raised with panic(sdl.GetError())) is written to a crash report
+// file and shown in a message box instead of the window just vanishing.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Helper function deferred first in Run: reports a panic and exits
+func (a *App) handleCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	report := fmt.Sprintf("%s crashed at %s: %v\n\n%s", a.Config.Title, time.Now().Format(time.RFC3339), r, debug.Stack())
+	fmt.Fprint(os.Stderr, report)
+
+	message := fmt.Sprintf("The application stopped because of an error:\n\n%v", r)
+	if path, err := a.writeCrashReport(report); err == nil {
+		message += "\n\nDetails were saved to " + path
+	}
+	// Shown without a parent, the window may already be destroyed (works without SDL_Init too)
+	ShowMessageBox(sdl.MessageBoxError, a.Config.Title, message, nil)
+	os.Exit(2) // Same status as an unrecovered panic
+}
+
+// Helper function saving a crash report next to the settings (or in the
+// temporary directory), returns the file path
+func (a *App) writeCrashReport(report string) (string, error) {
+	dir := os.TempDir()
+	if a.Config.AppName != "" {
+		dir = filepath.Dir(SettingsPath(a.Config.Organization, a.Config.AppName))
+	}
+	path := filepath.Join(dir, "crash-"+time.Now().Format("20060102-150405")+".log")
+	return path, os.WriteFile(path, []byte(report), 0o644)
+}
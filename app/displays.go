@@ -0,0 +1,115 @@
+// displays.go
+package app
+
+// Connected displays: where they are on the desktop, their refresh rate and
+// the UI scale the system uses on them, e.g. to place a second window or pick
+// a UI scale. OnDisplaysChanged reports displays being added, removed,
+// rearranged or changing mode. The App keeps the window on a display and
+// its text at the display's scale when docking or undocking a laptop.
+
+import (
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Display describes a connected display
+type Display struct {
+	ID           sdl.DisplayID
+	Name         string
+	Bounds       sdl.Rect // Desktop area, in screen coordinates
+	UsableBounds sdl.Rect // Without task bars and docks
+	Width        int32    // Current mode in pixels
+	Height       int32
+	RefreshRate  float32 // Hz, 0 if unknown
+	ContentScale float32 // System UI scale, e.g. 1.5 for 150%
+	Primary      bool
+}
+
+// Displays returns the connected displays
+func Displays() []Display {
+	primary := sdl.GetPrimaryDisplay()
+	var displays []Display
+	for _, id := range sdl.GetDisplays() {
+		displays = append(displays, describeDisplay(id, primary))
+	}
+	return displays
+}
+
+// WindowDisplay returns the display showing (most of) the window
+func (a *App) WindowDisplay() (Display, bool) {
+	id := sdl.GetDisplayForWindow(a.Window)
+	if id == 0 {
+		return Display{}, false
+	}
+	return describeDisplay(id, sdl.GetPrimaryDisplay()), true
+}
+
+// Helper function querying a display
+func describeDisplay(id, primary sdl.DisplayID) Display {
+	display := Display{ID: id, Name: sdl.GetDisplayName(id), ContentScale: sdl.GetDisplayContentScale(id), Primary: id == primary}
+	sdlext.GetDisplayBounds(id, &display.Bounds)
+	sdlext.GetDisplayUsableBounds(id, &display.UsableBounds)
+	if mode := sdl.GetCurrentDisplayMode(id); mode != nil {
+		display.Width, display.Height, display.RefreshRate = mode.W, mode.H, mode.RefreshRate
+	}
+	if display.ContentScale <= 0 {
+		display.ContentScale = 1
+	}
+	return display
+}
+
+// Helper function reacting to displays being added, removed or changed: the
+// window is moved back on screen, text follows the scale of its display, then
+// the application relayouts in OnDisplaysChanged
+func (a *App) displaysChanged() {
+	a.keepWindowOnScreen()
+	if ui.ApplyDisplayScale(a.Window, a.Renderer, a.Fonts) {
+		slog.Debug("display scale changed", "scale", sdl.GetWindowDisplayScale(a.Window))
+	}
+	if a.OnDisplaysChanged != nil {
+		a.OnDisplaysChanged()
+	}
+}
+
+// Helper function moving the window onto the nearest display, shrunk to fit
+// it, when its title bar is on no display (e.g. the display was unplugged).
+// Fullscreen and maximized windows are placed by the system.
+func (a *App) keepWindowOnScreen() {
+	if sdlext.GetWindowFlags(a.Window)&(sdl.WindowFullscreen|sdl.WindowMaximized|sdl.WindowMinimized) != 0 {
+		return
+	}
+	var x, y, w, h int32
+	sdl.GetWindowPosition(a.Window, &x, &y)
+	sdl.GetWindowSize(a.Window, &w, &h)
+	if onAnyDisplay(sdl.Rect{X: x, Y: y, W: w, H: minVisibleWindowPart}) {
+		return
+	}
+
+	display := sdl.GetDisplayForWindow(a.Window) // Closest one when off screen
+	if display == 0 {
+		display = sdl.GetPrimaryDisplay()
+	}
+	var bounds sdl.Rect
+	if !sdlext.GetDisplayUsableBounds(display, &bounds) {
+		slog.Warn("window not moved on screen", "error", sdl.GetError())
+		return
+	}
+	// The title bar and frame are outside the window's position and size
+	var top, left, bottom, right int32
+	sdlext.GetWindowBordersSize(a.Window, &top, &left, &bottom, &right)
+	bounds = sdl.Rect{X: bounds.X + left, Y: bounds.Y + top, W: bounds.W - left - right, H: bounds.H - top - bottom}
+
+	if w > bounds.W || h > bounds.H {
+		w, h = min(w, bounds.W), min(h, bounds.H)
+		sdl.SetWindowSize(a.Window, w, h)
+	}
+	x = max(bounds.X, min(x, bounds.X+bounds.W-w))
+	y = max(bounds.Y, min(y, bounds.Y+bounds.H-h))
+	slog.Debug("window moved back on screen", "display", sdl.GetDisplayName(display), "position", [2]int32{x, y})
+	sdl.SetWindowPosition(a.Window, x, y)
+	sdl.SyncWindow(a.Window)
+}
@@ -0,0 +1,30 @@
+// filedrop.go
+package app
+
+// Files and text dragged onto the window from other applications. SDL sends
+// one event per file between DropBegin and DropComplete, the paths are
+// collected and handed to OnFilesDropped together.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Helper function collecting dropped files and reporting complete drops
+func (a *App) handleDrop(event sdl.Event) {
+	drop := event.Drop()
+	switch event.Type() {
+	case sdl.EventDropBegin:
+		a.droppedFiles = a.droppedFiles[:0]
+	case sdl.EventDropFile:
+		a.droppedFiles = append(a.droppedFiles, drop.Data())
+	case sdl.EventDropText:
+		if a.OnTextDropped != nil {
+			a.OnTextDropped(drop.Data(), drop.X, drop.Y)
+		}
+	case sdl.EventDropComplete:
+		if len(a.droppedFiles) > 0 && a.OnFilesDropped != nil {
+			a.OnFilesDropped(a.droppedFiles, drop.X, drop.Y)
+		}
+		a.droppedFiles = nil
+	}
+}
@@ -0,0 +1,37 @@
+// frameclock.go
+package app
+
+// Frame timing: the per-frame delta time handed to widgets and the main loop
+// so movement and animations run at the same speed at any frame rate.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Longest delta a single frame may report (seconds), avoids huge jumps after stalls
+const maxFrameDelta = 0.1
+
+// FrameClock measures the time between frames
+type FrameClock struct {
+	Delta   float32 // Seconds since the previous frame
+	Elapsed float64 // Seconds since the clock started
+	last    uint64  // Nanoseconds
+}
+
+func NewFrameClock() *FrameClock {
+	return &FrameClock{last: sdl.GetTicksNS()}
+}
+
+// Tick starts a new frame and returns its delta time in seconds
+func (c *FrameClock) Tick() float32 {
+	now := sdl.GetTicksNS()
+	c.Delta = min(float32(now-c.last)/1e9, maxFrameDelta)
+	c.Elapsed += float64(c.Delta)
+	c.last = now
+	return c.Delta
+}
+
+// Reset restarts timing from now, so time spent idle does not count as a frame
+func (c *FrameClock) Reset() {
+	c.last = sdl.GetTicksNS()
+}
@@ -0,0 +1,147 @@
+// gamepads.go
+package app
+
+// Game controllers: pads are opened when they are plugged in (including the
+// ones present at startup) and closed when they go away. Their buttons and
+// sticks can be polled like the keyboard, or followed with callbacks.
+
+import (
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Stick positions closer to the center than this (0 to 1) read as 0
+const defaultDeadZone = 0.2
+
+// Gamepads tracks the connected game controllers and the state of their
+// buttons and axes, combined over all pads
+type Gamepads struct {
+	DeadZone     float32
+	OnConnect    func(id sdl.JoystickID, name string)
+	OnDisconnect func(id sdl.JoystickID)
+	OnButton     func(id sdl.JoystickID, button sdl.GamepadButton, down bool)
+
+	pads map[sdl.JoystickID]*gamepadState
+}
+
+// Open pad and what its controls last reported
+type gamepadState struct {
+	gamepad *sdl.Gamepad
+	buttons [sdl.GamepadButtonCount]bool
+	axes    [sdl.GamepadAxisCount]float32 // -1 to 1, triggers 0 to 1
+}
+
+func NewGamepads() *Gamepads {
+	return &Gamepads{DeadZone: defaultDeadZone, pads: make(map[sdl.JoystickID]*gamepadState)}
+}
+
+// Connected returns the ids of the open pads
+func (g *Gamepads) Connected() []sdl.JoystickID {
+	ids := make([]sdl.JoystickID, 0, len(g.pads))
+	for id := range g.pads {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Name returns the product name of a connected pad
+func (g *Gamepads) Name(id sdl.JoystickID) string {
+	if pad, ok := g.pads[id]; ok {
+		return sdl.GetGamepadName(pad.gamepad)
+	}
+	return ""
+}
+
+// Button reports whether button is held on any pad
+func (g *Gamepads) Button(button sdl.GamepadButton) bool {
+	if button < 0 || button >= sdl.GamepadButtonCount {
+		return false
+	}
+	for _, pad := range g.pads {
+		if pad.buttons[button] {
+			return true
+		}
+	}
+	return false
+}
+
+// Axis returns the position of axis (-1 to 1, triggers 0 to 1) on the pad
+// pushing it furthest, 0 inside the dead zone
+func (g *Gamepads) Axis(axis sdl.GamepadAxis) float32 {
+	if axis < 0 || axis >= sdl.GamepadAxisCount {
+		return 0
+	}
+	var value float32
+	for _, pad := range g.pads {
+		if v := pad.axes[axis]; abs(v) > abs(value) {
+			value = v
+		}
+	}
+	if abs(value) < g.DeadZone {
+		return 0
+	}
+	return value
+}
+
+// HandleEvent opens and closes pads and records their state, it never uses up the event
+func (g *Gamepads) HandleEvent(event sdl.Event) {
+	switch event.Type() {
+	case sdl.EventGamepadAdded:
+		id := event.GDevice().Which
+		if _, open := g.pads[id]; open {
+			return
+		}
+		gamepad := sdl.OpenGamepad(id)
+		if gamepad == nil {
+			slog.Warn("gamepad not opened", "error", sdl.GetError())
+			return
+		}
+		g.pads[id] = &gamepadState{gamepad: gamepad}
+		slog.Info("gamepad connected", "name", sdl.GetGamepadName(gamepad))
+		if g.OnConnect != nil {
+			g.OnConnect(id, sdl.GetGamepadName(gamepad))
+		}
+	case sdl.EventGamepadRemoved:
+		id := event.GDevice().Which
+		pad, open := g.pads[id]
+		if !open {
+			return
+		}
+		sdl.CloseGamepad(pad.gamepad)
+		delete(g.pads, id)
+		slog.Info("gamepad disconnected")
+		if g.OnDisconnect != nil {
+			g.OnDisconnect(id)
+		}
+	case sdl.EventGamepadAxisMotion:
+		axis := event.GAxis()
+		if pad, open := g.pads[axis.Which]; open && sdl.GamepadAxis(axis.Axis) < sdl.GamepadAxisCount {
+			pad.axes[axis.Axis] = max(float32(axis.Value)/32767, -1)
+		}
+	case sdl.EventGamepadButtonDown, sdl.EventGamepadButtonUp:
+		button := event.GButton()
+		if pad, open := g.pads[button.Which]; open && sdl.GamepadButton(button.Button) < sdl.GamepadButtonCount {
+			pad.buttons[button.Button] = button.Down
+			if g.OnButton != nil {
+				g.OnButton(button.Which, sdl.GamepadButton(button.Button), button.Down)
+			}
+		}
+	}
+}
+
+// Close closes all pads
+func (g *Gamepads) Close() {
+	for id, pad := range g.pads {
+		sdl.CloseGamepad(pad.gamepad)
+		delete(g.pads, id)
+	}
+}
+
+// Helper function returning the absolute value
+func abs(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+// geometry.go
+package app
+
+// Window geometry kept in Settings: the window reopens with the size,
+// position and maximized state it had when the application was closed.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// Part of the window (from its top left corner) that must be on a display
+// for a saved position to be used, so the title bar can still be grabbed
+const minVisibleWindowPart = 50
+
+// Helper function applying the saved size to the config, before the window is created
+func (a *App) loadWindowSize() {
+	a.Config.Width = int32(a.Settings.Int("window.width", int(a.Config.Width)))
+	a.Config.Height = int32(a.Settings.Int("window.height", int(a.Config.Height)))
+}
+
+// Helper function moving and maximizing the new window like it was saved
+func (a *App) restoreWindowGeometry() {
+	if a.Settings.Has("window.x") {
+		position := sdl.Rect{
+			X: int32(a.Settings.Int("window.x", 0)),
+			Y: int32(a.Settings.Int("window.y", 0)),
+			W: minVisibleWindowPart,
+			H: minVisibleWindowPart,
+		}
+		// Displays may have been unplugged or rearranged since, stay centered then
+		if onAnyDisplay(position) {
+			sdl.SetWindowPosition(a.Window, position.X, position.Y)
+		}
+	}
+	if a.Settings.Bool("window.maximized", false) {
+		sdlext.MaximizeWindow(a.Window)
+	}
+	a.trackWindowGeometry()
+}
+
+// Helper function reporting whether rect overlaps the usable area of a display
+func onAnyDisplay(rect sdl.Rect) bool {
+	for _, display := range sdl.GetDisplays() {
+		var bounds sdl.Rect
+		if sdlext.GetDisplayUsableBounds(display, &bounds) && sdl.HasRectIntersection(rect, bounds) {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper function remembering the window geometry while it is a normal window.
+// A maximized or fullscreen window keeps the geometry to return to.
+func (a *App) trackWindowGeometry() {
+	if sdlext.GetWindowFlags(a.Window)&(sdl.WindowMaximized|sdl.WindowMinimized|sdl.WindowFullscreen) != 0 {
+		return
+	}
+	var x, y, w, h int32
+	sdl.GetWindowPosition(a.Window, &x, &y)
+	sdl.GetWindowSize(a.Window, &w, &h)
+	a.windowGeometry = sdl.Rect{X: x, Y: y, W: w, H: h}
+}
+
+// Helper function storing the window geometry in the settings
+func (a *App) saveWindowGeometry() {
+	a.trackWindowGeometry()
+	a.Settings.SetInt("window.x", int(a.windowGeometry.X))
+	a.Settings.SetInt("window.y", int(a.windowGeometry.Y))
+	a.Settings.SetInt("window.width", int(a.windowGeometry.W))
+	a.Settings.SetInt("window.height", int(a.windowGeometry.H))
+	a.Settings.SetBool("window.maximized", sdlext.GetWindowFlags(a.Window)&sdl.WindowMaximized != 0)
+}
@@ -0,0 +1,76 @@
+// hotkeys.go
+package app
+
+// Global hotkeys: accelerators registered with the operating system fire even
+// while another application has focus, e.g. to summon a tray-style app. Only
+// Windows offers them (RegisterHotKey), elsewhere registering fails with
+// ErrGlobalHotkeysUnsupported and the accelerator can still be a Shortcut.
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrGlobalHotkeysUnsupported = errors.New("global hotkeys are not supported on this platform")
+
+// Registered global hotkey
+type globalHotkey struct {
+	id int32
+	fn func()
+}
+
+// RegisterGlobalHotkey calls fn on the main thread whenever accelerator (see
+// ParseShortcut) is pressed, whichever application has focus. Call it on the
+// main thread after Run started, e.g. in OnInit. Registering an accelerator
+// again replaces its function.
+func (a *App) RegisterGlobalHotkey(accelerator string, fn func()) error {
+	shortcut, err := ParseShortcut(accelerator)
+	if err != nil {
+		return err
+	}
+	if hotkey, ok := a.hotkeys[shortcut]; ok {
+		hotkey.fn = fn
+		return nil
+	}
+	id := a.nextHotkeyID + 1
+	if err := registerGlobalHotkey(id, shortcut, a.globalHotkeyPressed); err != nil {
+		return fmt.Errorf("global hotkey %q: %w", accelerator, err)
+	}
+	if a.hotkeys == nil {
+		a.hotkeys = make(map[Shortcut]*globalHotkey)
+	}
+	a.hotkeys[shortcut] = &globalHotkey{id: id, fn: fn}
+	a.nextHotkeyID = id
+	return nil
+}
+
+// UnregisterGlobalHotkey gives accelerator back to the system
+func (a *App) UnregisterGlobalHotkey(accelerator string) {
+	shortcut, err := ParseShortcut(accelerator)
+	if err != nil {
+		return
+	}
+	if hotkey, ok := a.hotkeys[shortcut]; ok {
+		unregisterGlobalHotkey(hotkey.id)
+		delete(a.hotkeys, shortcut)
+	}
+}
+
+// Helper function unregistering all global hotkeys when the App quits
+func (a *App) unregisterGlobalHotkeys() {
+	for shortcut, hotkey := range a.hotkeys {
+		unregisterGlobalHotkey(hotkey.id)
+		delete(a.hotkeys, shortcut)
+	}
+}
+
+// Helper function running the hotkey with id. The system reports it while
+// SDL pumps its events, so the function runs with the next loop iteration.
+func (a *App) globalHotkeyPressed(id int32) {
+	for _, hotkey := range a.hotkeys {
+		if hotkey.id == id {
+			a.RunOnMainThread(hotkey.fn)
+			return
+		}
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package app
+
+// Helper function registering a global hotkey with the system
+func registerGlobalHotkey(id int32, shortcut Shortcut, pressed func(id int32)) error {
+	return ErrGlobalHotkeysUnsupported
+}
+
+// Helper function unregistering a global hotkey
+func unregisterGlobalHotkey(id int32) {}
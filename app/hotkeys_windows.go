@@ -0,0 +1,104 @@
+// hotkeys_windows.go
+package app
+
+// Global hotkeys on Windows: RegisterHotKey without a window posts WM_HOTKEY to
+// the main thread's message queue, an SDL message hook turns it into a call of
+// the App's handler while SDL pumps events.
+
+import (
+	"errors"
+	"sync"
+	"syscall"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+var (
+	user32               = syscall.NewLazyDLL("user32.dll")
+	procRegisterHotKey   = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey = user32.NewProc("UnregisterHotKey")
+	hotkeyHookOnce       sync.Once // The message hook serves all hotkeys
+)
+
+// Win32 constants of RegisterHotKey
+const (
+	wmHotkey     = 0x0312
+	modAlt       = 0x0001
+	modControl   = 0x0002
+	modShift     = 0x0004
+	modWin       = 0x0008
+	modNoRepeat  = 0x4000 // Holding the keys fires once
+	hotkeyThread = 0      // No window: WM_HOTKEY goes to the registering (main) thread
+)
+
+// Helper function registering a global hotkey with the system. WM_HOTKEY is
+// posted to the main thread's queue, the SDL message hook picks it up there.
+func registerGlobalHotkey(id int32, shortcut Shortcut, pressed func(id int32)) error {
+	key, ok := virtualKey(shortcut.Key)
+	if !ok {
+		return errors.New("key can't be a global hotkey")
+	}
+	modifiers := uintptr(modNoRepeat)
+	for _, modifier := range []struct {
+		sdl   sdl.Keymod
+		win32 uintptr
+	}{{sdl.KeymodAlt, modAlt}, {sdl.KeymodCtrl, modControl}, {sdl.KeymodShift, modShift}, {sdl.KeymodGui, modWin}} {
+		if shortcut.Mod&modifier.sdl != 0 {
+			modifiers |= modifier.win32
+		}
+	}
+	if ok, _, err := procRegisterHotKey.Call(hotkeyThread, uintptr(id), modifiers, key); ok == 0 {
+		return err // E.g. taken by another application
+	}
+	hotkeyHookOnce.Do(func() {
+		sdlext.SetWindowsMessageHook(func(msg *sdlext.WindowsMessage) bool {
+			if msg.Message != wmHotkey {
+				return true
+			}
+			pressed(int32(msg.WParam))
+			return false
+		})
+	})
+	return nil
+}
+
+// Helper function unregistering a global hotkey
+func unregisterGlobalHotkey(id int32) {
+	procUnregisterHotKey.Call(hotkeyThread, uintptr(id))
+}
+
+// Helper function returning the Win32 virtual key code of key
+func virtualKey(key sdl.Keycode) (uintptr, bool) {
+	switch {
+	case key >= sdl.KeycodeA && key <= sdl.KeycodeZ:
+		return uintptr('A' + key - sdl.KeycodeA), true
+	case key >= sdl.Keycode0 && key <= sdl.Keycode9:
+		return uintptr(key), true // Same as ASCII
+	case key >= sdl.KeycodeF1 && key <= sdl.KeycodeF12:
+		return uintptr(0x70 + key - sdl.KeycodeF1), true
+	case key >= sdl.KeycodeF13 && key <= sdl.KeycodeF24:
+		return uintptr(0x7C + key - sdl.KeycodeF13), true
+	}
+	codes := map[sdl.Keycode]uintptr{
+		sdl.KeycodeSpace:       0x20,
+		sdl.KeycodeReturn:      0x0D,
+		sdl.KeycodeEscape:      0x1B,
+		sdl.KeycodeTab:         0x09,
+		sdl.KeycodePageUp:      0x21,
+		sdl.KeycodePageDown:    0x22,
+		sdl.KeycodeEnd:         0x23,
+		sdl.KeycodeHome:        0x24,
+		sdl.KeycodeLeft:        0x25,
+		sdl.KeycodeUp:          0x26,
+		sdl.KeycodeRight:       0x27,
+		sdl.KeycodeDown:        0x28,
+		sdl.KeycodePrintScreen: 0x2C,
+		sdl.KeycodeInsert:      0x2D,
+		sdl.KeycodeDelete:      0x2E,
+		sdl.KeycodePause:       0x13,
+	}
+	code, ok := codes[key]
+	return code, ok
+}
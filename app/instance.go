@@ -0,0 +1,113 @@
+// instance.go
+package app
+
+// Single instance: the first copy of the application listens on a local
+// socket in the preference directory. Later copies connect to it, send their
+// command line and exit, the first one raises its window.
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// Seconds a later copy waits for the running one to take its command line
+const instanceTimeout = 2
+
+// Command line handed to the running copy
+type instanceMessage struct {
+	Args []string `json:"args"`
+	Dir  string   `json:"dir"`
+}
+
+// Helper function returning the socket path of the running copy
+func (a *App) instancePath() string {
+	dir := sdlext.GetPrefPath(a.Config.Organization, a.Config.AppName)
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "instance.sock")
+}
+
+// Helper function sending the command line to a running copy, returns true
+// if there was one. Otherwise this copy starts listening for later ones.
+func (a *App) forwardToRunningInstance() bool {
+	if a.Config.AppName == "" {
+		slog.Warn("single instance needs Config.AppName")
+		return false
+	}
+	path := a.instancePath()
+	dir, _ := os.Getwd()
+	message := instanceMessage{Args: os.Args[1:], Dir: dir}
+	if conn, err := net.DialTimeout("unix", path, instanceTimeout*time.Second); err == nil {
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(instanceTimeout * time.Second))
+		if err := json.NewEncoder(conn).Encode(message); err != nil {
+			slog.Warn("command line not handed to the running instance", "error", err)
+		}
+		slog.Info("already running, handed over to that instance")
+		return true
+	}
+
+	// No one listens, the socket file is left from a copy that crashed
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("stale instance socket not removed", "path", path, "error", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		slog.Warn("single instance not enforced", "error", err)
+		return false
+	}
+	a.instance = listener
+	go a.acceptInstances(listener)
+	return false
+}
+
+// Helper function receiving the command lines of later copies until the
+// listener is closed
+func (a *App) acceptInstances(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return // Closed on quit
+		}
+		var message instanceMessage
+		conn.SetDeadline(time.Now().Add(instanceTimeout * time.Second))
+		err = json.NewDecoder(conn).Decode(&message)
+		conn.Close()
+		if err != nil {
+			slog.Warn("command line of a second instance not read", "error", err)
+			continue
+		}
+		a.RunOnMainThread(func() { a.secondInstance(message) })
+	}
+}
+
+// Helper function bringing the window to the front for a later copy
+func (a *App) secondInstance(message instanceMessage) {
+	slog.Debug("second instance started", "args", message.Args)
+	if a.Window != nil {
+		sdl.RestoreWindow(a.Window) // Unminimize
+		sdl.RaiseWindow(a.Window)
+	}
+	if a.OnSecondInstance != nil {
+		a.OnSecondInstance(message.Args, message.Dir)
+	}
+}
+
+// Helper function closing the socket so the next start becomes the running copy
+func (a *App) closeInstanceListener() {
+	if a.instance != nil {
+		a.instance.Close() // Also removes the socket file
+		a.instance = nil
+	}
+}
@@ -0,0 +1,35 @@
+// keys.go
+package app
+
+// Held-key state for continuous input: poll it every frame and scale by dt
+// instead of reacting to key events, which only arrive on press and at the
+// keyboard's repeat rate. Poll from App.OnTick and return true while a key is
+// held, so the loop keeps running instead of waiting for the next event.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// KeyHeld reports whether any of the keys is down right now
+func KeyHeld(keys ...sdl.Scancode) bool {
+	state := sdl.GetKeyboardState()
+	for _, key := range keys {
+		if int(key) < len(state) && state[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyAxis returns -1 while negative is held, 1 while positive is held and 0
+// for neither or both, e.g. KeyAxis(sdl.ScancodeLeft, sdl.ScancodeRight)
+func KeyAxis(negative, positive sdl.Scancode) float32 {
+	var value float32
+	if KeyHeld(negative) {
+		value--
+	}
+	if KeyHeld(positive) {
+		value++
+	}
+	return value
+}
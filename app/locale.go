@@ -0,0 +1,53 @@
+// locale.go
+package app
+
+// The user's languages, so the UI can start in one of them: MatchLocale picks
+// the best of the translations an application has. OnLocaleChanged reports
+// the user changing them while the application runs.
+
+import (
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// PreferredLocales returns the user's languages as tags like "en-US" or "fr",
+// most preferred first (empty if the system doesn't say)
+func PreferredLocales() []string {
+	var tags []string
+	for _, locale := range sdl.GetPreferredLocales() {
+		tag := locale.Language
+		if locale.Country != "" {
+			tag += "-" + locale.Country
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// MatchLocale returns the tag in available (e.g. "en", "pt-BR") best matching
+// the user's languages: the most preferred language available wins, with its
+// country if there is a translation for it. Returns fallback if none matches.
+func MatchLocale(available []string, fallback string) string {
+	for _, preferred := range PreferredLocales() {
+		language, _, _ := strings.Cut(normalizeLocale(preferred), "-")
+		languageOnly := ""
+		for _, tag := range available {
+			switch normalizeLocale(tag) {
+			case normalizeLocale(preferred):
+				return tag
+			case language:
+				languageOnly = tag
+			}
+		}
+		if languageOnly != "" {
+			return languageOnly
+		}
+	}
+	return fallback
+}
+
+// Helper function writing a locale tag as "language-country" in lower case
+func normalizeLocale(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+}
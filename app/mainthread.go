@@ -0,0 +1,54 @@
+// mainthread.go
+package app
+
+// SDL must only be called from the main thread. Goroutines hand work to it
+// with RunOnMainThread, the loop runs the queued functions every iteration
+// and a user event wakes an idle loop right away.
+
+import (
+	"encoding/binary"
+	"runtime"
+	"sync"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+func init() {
+	// The main goroutine starts on the main thread, keep it there for SDL
+	runtime.LockOSThread()
+}
+
+// RunOnMainThread queues fn to run on the main thread before the next update,
+// e.g. to change labels or textures with the result of background work. Safe
+// to call from any goroutine (the main thread too), never blocks: the queue
+// grows as needed and fn runs later.
+func (a *App) RunOnMainThread(fn func()) {
+	a.mainThread.Lock()
+	a.mainThread.funcs = append(a.mainThread.funcs, fn)
+	a.mainThread.Unlock()
+	if eventType := a.wakeEvent.Load(); eventType != 0 {
+		var event sdl.Event
+		binary.NativeEndian.PutUint32(event[:4], eventType)
+		sdl.PushEvent(&event) // Thread-safe, ends WaitEventTimeout
+	}
+}
+
+// Helper function running the functions queued so far, returns true if there
+// were any. Functions they queue wait for the next iteration (their wake event
+// keeps the loop from idling), so one that queues itself can't stall the loop.
+func (a *App) runMainThreadFuncs() bool {
+	a.mainThread.Lock()
+	funcs := a.mainThread.funcs
+	a.mainThread.funcs = nil
+	a.mainThread.Unlock()
+	for _, fn := range funcs {
+		fn()
+	}
+	return len(funcs) > 0
+}
+
+// Functions waiting for the main thread
+type mainThreadQueue struct {
+	sync.Mutex
+	funcs []func()
+}
@@ -0,0 +1,39 @@
+// messagebox.go
+package app
+
+// Native message boxes: shown by the system, so they work before the window
+// and renderer exist (even before SDL_Init), e.g. to tell users launching the
+// application from a desktop icon that a font file is missing.
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// ShowMessageBox shows message and waits until the user closes the box. kind
+// is sdl.MessageBoxError, sdl.MessageBoxWarning or sdl.MessageBoxInformation,
+// parent may be nil. Without a display the message goes to stderr.
+func ShowMessageBox(kind sdl.MessageBoxFlags, title, message string, parent *sdl.Window) {
+	if !sdl.ShowSimpleMessageBox(kind, title, message, parent) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", title, message)
+	}
+}
+
+// ShowMessageBox shows message over the window (if it exists yet) with the
+// application title. Relative mouse mode is suspended while the box is open.
+func (a *App) ShowMessageBox(kind sdl.MessageBoxFlags, message string) {
+	if kind&sdl.MessageBoxError != 0 {
+		ui.PlaySound(ui.SoundError)
+	} else {
+		ui.PlaySound(ui.SoundNotification)
+	}
+	if a.Window != nil && a.relativeMouse {
+		sdl.SetWindowRelativeMouseMode(a.Window, false)
+		defer a.applyRelativeMouse()
+	}
+	ShowMessageBox(kind, a.Config.Title, message, a.Window)
+}
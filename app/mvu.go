@@ -0,0 +1,190 @@
+// mvu.go
+package app
+
+// Opt-in Model-View-Update architecture: the application state is one model
+// value, messages change it only through a pure update function, and a view
+// function describes the widgets for a model. After every update the new
+// description is compared with the previous one and only the widgets that
+// differ are changed, created or destroyed.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Kinds of view nodes
+type nodeKind int
+
+const (
+	nodeText nodeKind = iota
+	nodeButton
+	nodeRow
+	nodeColumn
+)
+
+// Node describes a widget in a view, built with Text, Button, Row and Column
+type Node struct {
+	kind     nodeKind
+	text     string
+	msg      any // Dispatched when a button is clicked
+	children []Node
+}
+
+// Text describes a label
+func Text(text string) Node {
+	return Node{kind: nodeText, text: text}
+}
+
+// Button describes a button dispatching msg when clicked
+func Button(text string, msg any) Node {
+	return Node{kind: nodeButton, text: text, msg: msg}
+}
+
+// Row describes children laid out left to right
+func Row(children ...Node) Node {
+	return Node{kind: nodeRow, children: children}
+}
+
+// Column describes children stacked top to bottom
+func Column(children ...Node) Node {
+	return Node{kind: nodeColumn, children: children}
+}
+
+// Spacing between the widgets of rows and columns
+const viewSpacing = 10
+
+// Program runs a model with its update and view functions. It is a Scene,
+// push it on App.Scenes to show it.
+type Program[Model any] struct {
+	SceneBase
+	Model      Model
+	UpdateFunc func(msg any, model Model) Model // Returns the model after msg, without side effects
+	ViewFunc   func(model Model) Node
+
+	app     *App
+	root    *ui.Layout // Holds the widget built for the view, positions it
+	view    Node
+	pending []any // Messages dispatched since the last Update
+}
+
+// NewProgram creates a program showing view(model) at x, y
+func NewProgram[Model any](model Model, update func(msg any, model Model) Model, view func(model Model) Node, x, y float32) *Program[Model] {
+	return &Program[Model]{Model: model, UpdateFunc: update, ViewFunc: view, root: ui.NewLayout(x, y, 0)}
+}
+
+// Dispatch queues msg for the update function (applied before the next frame)
+func (p *Program[Model]) Dispatch(msg any) {
+	p.pending = append(p.pending, msg)
+	if p.app != nil {
+		p.app.Redraw()
+	}
+}
+
+func (p *Program[Model]) Enter(app *App) {
+	p.app = app
+	p.view = p.ViewFunc(p.Model)
+	p.root.AddWidget(p.build(p.view))
+}
+
+func (p *Program[Model]) Exit() {
+	p.root.Destroy()
+	p.root.Widgets = nil
+}
+
+func (p *Program[Model]) Event(event sdl.Event) {
+	mx, my := ui.EventPosition(event)
+	if p.root.Update(event, mx, my) {
+		p.app.Redraw() // E.g. a hover highlight moved with the mouse
+	}
+}
+
+// Update applies the queued messages and brings the widgets up to date with the new view
+func (p *Program[Model]) Update(dt float32) bool {
+	if len(p.pending) == 0 {
+		return p.root.Tick(dt)
+	}
+	for len(p.pending) > 0 {
+		msg := p.pending[0]
+		p.pending = p.pending[1:]
+		p.Model = p.UpdateFunc(msg, p.Model)
+	}
+	next := p.ViewFunc(p.Model)
+	p.root.Widgets[0] = p.patch(p.root.Widgets[0], p.view, next)
+	p.view = next
+	p.root.Relayout()
+	p.root.Tick(dt)
+	return true
+}
+
+func (p *Program[Model]) Render(renderer *sdl.Renderer) {
+	p.root.Render(renderer)
+}
+
+// Helper function creating the widgets for a node
+func (p *Program[Model]) build(node Node) ui.Widget {
+	switch node.kind {
+	case nodeButton:
+		button := ui.NewButton(0, 0, 0, 0, node.text, p.app.Font, p.app.Renderer, nil)
+		p.bindClick(button, node.msg)
+		return button
+	case nodeRow, nodeColumn:
+		layout := ui.NewLayout(0, 0, viewSpacing)
+		layout.Vertical = node.kind == nodeColumn
+		for _, child := range node.children {
+			layout.AddWidget(p.build(child))
+		}
+		return layout
+	default:
+		return ui.NewLabel(0, 0, node.text, p.app.Font, p.app.Renderer)
+	}
+}
+
+// Helper function making a button dispatch msg
+func (p *Program[Model]) bindClick(button *ui.Button, msg any) {
+	button.OnClick = func() {
+		p.Dispatch(msg)
+	}
+}
+
+// Helper function updating widget (built from old) to match node, returns the widget to use
+func (p *Program[Model]) patch(widget ui.Widget, old, node Node) ui.Widget {
+	if old.kind != node.kind {
+		destroyWidget(widget)
+		return p.build(node)
+	}
+	switch node.kind {
+	case nodeText:
+		if label := widget.(*ui.Label); label.Text != node.text {
+			label.UpdateText(node.text)
+		}
+	case nodeButton:
+		button := widget.(*ui.Button)
+		if button.Text != node.text {
+			button.SetText(node.text)
+		}
+		p.bindClick(button, node.msg)
+	case nodeRow, nodeColumn:
+		layout := widget.(*ui.Layout)
+		layout.Vertical = node.kind == nodeColumn
+		common := min(len(old.children), len(node.children))
+		for i := range common {
+			layout.Widgets[i] = p.patch(layout.Widgets[i], old.children[i], node.children[i])
+		}
+		for _, removed := range layout.Widgets[common:] {
+			destroyWidget(removed)
+		}
+		layout.Widgets = layout.Widgets[:common]
+		for _, added := range node.children[common:] {
+			layout.AddWidget(p.build(added))
+		}
+	}
+	return widget
+}
+
+// Helper function freeing a widget created for a view
+func destroyWidget(widget ui.Widget) {
+	if destroyer, ok := widget.(interface{ Destroy() }); ok {
+		destroyer.Destroy()
+	}
+}
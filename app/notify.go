@@ -0,0 +1,43 @@
+// notify.go
+package app
+
+// Desktop notifications: background work can alert the user while the window
+// is minimized or behind other windows. The system shows them where it can
+// (notify-send on Linux, Notification Center on macOS, toasts on Windows),
+// elsewhere they become toasts in the window, which also flashes.
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+var ErrNotificationsUnsupported = errors.New("desktop notifications are not supported on this platform")
+
+// Notify shows a desktop notification with a title and a body. icon is an
+// image file path or (on Linux) a themed icon name, "" for none. Returns
+// right away, the system is asked in the background. Call it on the main
+// thread (RunOnMainThread from background work).
+func (a *App) Notify(title, body, icon string) {
+	appName := a.Config.AppName
+	if appName == "" {
+		appName = a.Config.Title
+	}
+	go func() {
+		if err := notify(appName, title, body, icon); err != nil {
+			slog.Debug("desktop notification not shown, using a toast", "error", err)
+			a.RunOnMainThread(func() { a.toast(title, body) })
+		}
+	}()
+}
+
+// Helper function showing a notification in the window and drawing the
+// user's attention to it
+func (a *App) toast(title, body string) {
+	a.Toasts.Show(title, body)
+	a.needsRedraw = true
+	if sdl.GetKeyboardFocus() != a.Window {
+		sdl.FlashWindow(a.Window, sdl.FlashUntilFocused)
+	}
+}
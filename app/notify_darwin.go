@@ -0,0 +1,18 @@
+package app
+
+import (
+	"os/exec"
+)
+
+// Helper function showing a notification in the Notification Center with
+// AppleScript (no icon, it shows the one of the script runner)
+func notify(appName, title, body, icon string) error {
+	// Arguments instead of string literals, nothing needs quoting
+	script := []string{
+		"-e", "on run argv",
+		"-e", "display notification (item 3 of argv) with title (item 1 of argv) subtitle (item 2 of argv)",
+		"-e", "end run",
+	}
+	args := append(script, appName, title, body)
+	return exec.Command("osascript", args...).Run()
+}
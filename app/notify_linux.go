@@ -0,0 +1,19 @@
+package app
+
+import (
+	"os/exec"
+)
+
+// Helper function showing a notification with notify-send (libnotify)
+func notify(appName, title, body, icon string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return ErrNotificationsUnsupported
+	}
+	args := []string{"--app-name=" + appName}
+	if icon != "" {
+		args = append(args, "--icon="+icon)
+	}
+	args = append(args, "--", title, body)
+	return exec.Command(path, args...).Run()
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package app
+
+// Helper function showing a desktop notification
+func notify(appName, title, body, icon string) error {
+	return ErrNotificationsUnsupported
+}
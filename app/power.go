@@ -0,0 +1,71 @@
+// power.go
+package app
+
+// Power supply: whether the device runs on battery, how full it is and
+// whether it charges. SDL has no event for it, so OnPowerChanged polls.
+// KeepAwake stops the display from sleeping.
+
+import (
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// PowerInfo is the state of the power supply
+type PowerInfo struct {
+	State   sdl.PowerState // sdl.PowerStateOnBattery, NoBattery (plugged in), Charging, Charged or Unknown
+	Percent int32          // Battery charge from 0 to 100, -1 if unknown
+	Seconds int32          // Battery time left, -1 if unknown
+}
+
+// GetPowerInfo reads the current power supply state
+func GetPowerInfo() PowerInfo {
+	var info PowerInfo
+	info.State = sdl.GetPowerInfo(&info.Seconds, &info.Percent)
+	return info
+}
+
+// HasBattery reports whether the device has a battery (in use or charging)
+func (p PowerInfo) HasBattery() bool {
+	return p.State == sdl.PowerStateOnBattery || p.State == sdl.PowerStateCharging || p.State == sdl.PowerStateCharged
+}
+
+// Charging reports whether the battery is plugged in and charging
+func (p PowerInfo) Charging() bool {
+	return p.State == sdl.PowerStateCharging
+}
+
+// OnPowerChanged calls fn right away and then whenever the power state or
+// battery percentage changed, checking every interval seconds. Cancel the
+// timer to stop.
+func (a *App) OnPowerChanged(interval float32, fn func(info PowerInfo)) *Timer {
+	last := GetPowerInfo()
+	fn(last)
+	return a.Every(interval, func() {
+		info := GetPowerInfo()
+		if info.State != last.State || info.Percent != last.Percent {
+			last = info
+			fn(info)
+		}
+	})
+}
+
+// KeepAwake keeps the display from blanking or sleeping while the app runs
+// (e.g. during playback), false lets the screensaver start again. Config.KeepAwake
+// is applied this way when the window opens.
+func (a *App) KeepAwake(awake bool) {
+	change := sdlext.EnableScreenSaver
+	if awake {
+		change = sdlext.DisableScreenSaver
+	}
+	if !change() {
+		slog.Warn("screensaver not changed", "error", sdl.GetError())
+	}
+}
+
+// KeepingAwake reports whether the display is kept from sleeping
+func (a *App) KeepingAwake() bool {
+	return !sdlext.ScreenSaverEnabled()
+}
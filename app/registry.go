@@ -0,0 +1,84 @@
+// registry.go
+package app
+
+// Commands and dialogs by name, so packages can add them from an init
+// function without changing the code that offers them (menus, UI files
+// publishing actions, command palettes). Widget types are registered in the
+// ui package (see ui.RegisterWidget).
+
+import (
+	"fmt"
+	"sort"
+
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+var (
+	commandFactories = make(map[string]func() Command)
+	dialogFactories  = make(map[string]func() Scene)
+)
+
+// RegisterCommand makes a command available under name, factory creates a new
+// instance each time it runs (commands keep their own undo state). It panics
+// if the name is taken.
+func RegisterCommand(name string, factory func() Command) {
+	if _, taken := commandFactories[name]; taken {
+		panic(fmt.Sprintf("app: command %q registered twice", name))
+	}
+	commandFactories[name] = factory
+}
+
+// NewCommand creates the command registered under name, or returns nil
+func NewCommand(name string) Command {
+	if factory, ok := commandFactories[name]; ok {
+		return factory()
+	}
+	return nil
+}
+
+// Commands lists the registered command names in order (e.g. to fill a menu)
+func Commands() []string {
+	names := make([]string, 0, len(commandFactories))
+	for name := range commandFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterDialog makes a dialog available under name, factory creates the
+// scene showing it. It panics if the name is taken.
+func RegisterDialog(name string, factory func() Scene) {
+	if _, taken := dialogFactories[name]; taken {
+		panic(fmt.Sprintf("app: dialog %q registered twice", name))
+	}
+	dialogFactories[name] = factory
+}
+
+// OpenDialog pushes the dialog registered under name, returns false if there is none
+func (a *App) OpenDialog(name string) bool {
+	factory, ok := dialogFactories[name]
+	if !ok {
+		return false
+	}
+	dialog := factory()
+	if a.dialogs == nil {
+		a.dialogs = make(map[Scene]struct{})
+	}
+	a.dialogs[dialog] = struct{}{}
+	a.Scenes.Push(dialog)
+	a.applyRelativeMouse()
+	ui.PlaySound(ui.SoundNotification)
+	return true
+}
+
+// DoNamed executes the command registered under name (so it can be undone),
+// returns false if there is none
+func (s *CommandStack) DoNamed(name string) bool {
+	cmd := NewCommand(name)
+	if cmd == nil {
+		return false
+	}
+	s.Do(cmd)
+	return true
+}
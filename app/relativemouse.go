@@ -0,0 +1,50 @@
+// relativemouse.go
+package app
+
+// Relative mouse mode: the cursor is hidden and held in the window, and motion
+// events report how far the mouse moved (Xrel, Yrel) without ever stopping at
+// the window edge, e.g. to turn a camera or pan a canvas indefinitely. Dialogs
+// need the cursor, so the mode is suspended while one is open.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// SetRelativeMouse turns relative mouse mode on or off. While a dialog opened
+// with OpenDialog is on the scene stack the cursor is back, the mode returns
+// when the dialog closes.
+func (a *App) SetRelativeMouse(enabled bool) {
+	a.relativeMouse = enabled
+	a.applyRelativeMouse()
+}
+
+// RelativeMouse reports whether relative mouse mode was requested (it may be
+// suspended by a dialog)
+func (a *App) RelativeMouse() bool {
+	return a.relativeMouse
+}
+
+// Helper function switching SDL's relative mode to what was requested, unless a dialog is open
+func (a *App) applyRelativeMouse() {
+	enabled := a.relativeMouse && !a.dialogOpen()
+	if a.Window == nil || enabled == sdl.GetWindowRelativeMouseMode(a.Window) {
+		return
+	}
+	if !sdl.SetWindowRelativeMouseMode(a.Window, enabled) {
+		a.relativeMouse = false // Not supported here, don't retry every frame
+	}
+}
+
+// Helper function reporting whether a scene opened by OpenDialog is on the
+// stack, forgetting dialogs that were closed
+func (a *App) dialogOpen() bool {
+	open := false
+	for dialog := range a.dialogs {
+		if a.Scenes.contains(dialog) {
+			open = true
+		} else {
+			delete(a.dialogs, dialog)
+		}
+	}
+	return open
+}
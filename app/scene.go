@@ -0,0 +1,220 @@
+// scene.go
+package app
+
+// Scenes are the screens of an application (menu, settings, content), each
+// owning its widgets and state. The SceneManager keeps them on a stack: the
+// top scene gets events, updates and is drawn, changes can be animated.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Scene is one screen of the application
+type Scene interface {
+	Enter(app *App)         // Became part of the stack (Push or Replace), create widgets here
+	Exit()                  // Left the stack (Pop or Replace), free widgets here
+	Event(event sdl.Event)  // Events while the scene is on top
+	Update(dt float32) bool // Returns true while the scene needs frames (animations)
+	Render(renderer *sdl.Renderer)
+}
+
+// FixedUpdater is implemented by scenes with game-style state advanced by the
+// fixed timestep (see Config.FixedTimestep) while they are on top
+type FixedUpdater interface {
+	FixedUpdate(step float32)
+}
+
+// SceneBase implements every Scene method as a no-op, embed it to only write the ones needed
+type SceneBase struct{}
+
+func (SceneBase) Enter(app *App)                {}
+func (SceneBase) Exit()                         {}
+func (SceneBase) Event(event sdl.Event)         {}
+func (SceneBase) Update(dt float32) bool        { return false }
+func (SceneBase) Render(renderer *sdl.Renderer) {}
+
+// TransitionKind selects how scene changes are animated
+type TransitionKind int
+
+const (
+	TransitionNone  TransitionKind = iota // Switch instantly
+	TransitionFade                        // New scene fades in over the old one
+	TransitionSlide                       // New scene slides in from the right, a popped one slides back out
+)
+
+// Transition describes the animation of scene changes
+type Transition struct {
+	Kind     TransitionKind
+	Duration float32 // Seconds
+}
+
+// SceneManager holds the scene stack
+type SceneManager struct {
+	Transition Transition // Used for every change, the zero value switches instantly
+
+	app       *App
+	stack     []Scene
+	leaving   Scene   // Scene animated away (nil when no transition runs)
+	exitAfter bool    // Exit leaving when the transition ends (popped or replaced)
+	backwards bool    // Transition started by Pop
+	progress  float32 // 0 to 1
+	started   uint64  // sdl.GetTicksNS time the transition started
+	target    ui.RenderTarget
+}
+
+func NewSceneManager(app *App) *SceneManager {
+	return &SceneManager{app: app}
+}
+
+// Current returns the top scene, or nil
+func (m *SceneManager) Current() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// Len returns the number of scenes on the stack
+func (m *SceneManager) Len() int {
+	return len(m.stack)
+}
+
+// Helper function reporting whether scene is on the stack
+func (m *SceneManager) contains(scene Scene) bool {
+	for _, stacked := range m.stack {
+		if stacked == scene {
+			return true
+		}
+	}
+	return false
+}
+
+// Push puts scene on top, the scene below keeps its state until it is uncovered
+func (m *SceneManager) Push(scene Scene) {
+	m.finishTransition()
+	previous := m.Current()
+	m.stack = append(m.stack, scene)
+	scene.Enter(m.app)
+	m.startTransition(previous, false, false)
+}
+
+// Pop removes the top scene and uncovers the one below
+func (m *SceneManager) Pop() {
+	m.finishTransition()
+	top := m.Current()
+	if top == nil {
+		return
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	m.startTransition(top, true, true)
+}
+
+// Replace swaps the top scene for scene (e.g. menu to game)
+func (m *SceneManager) Replace(scene Scene) {
+	m.finishTransition()
+	top := m.Current()
+	if top != nil {
+		m.stack = m.stack[:len(m.stack)-1]
+	}
+	m.stack = append(m.stack, scene)
+	scene.Enter(m.app)
+	m.startTransition(top, true, false)
+}
+
+// Clear exits every scene, top first (the App does this on shutdown)
+func (m *SceneManager) Clear() {
+	m.finishTransition()
+	for len(m.stack) > 0 {
+		top := m.stack[len(m.stack)-1]
+		m.stack = m.stack[:len(m.stack)-1]
+		top.Exit()
+	}
+	m.target.Destroy()
+}
+
+// Helper function starting the animation away from leaving (exited right away without a transition)
+func (m *SceneManager) startTransition(leaving Scene, exit, backwards bool) {
+	if leaving == nil {
+		return
+	}
+	m.leaving, m.exitAfter, m.backwards, m.progress = leaving, exit, backwards, 0
+	m.started = sdl.GetTicksNS()
+	if m.Transition.Kind == TransitionNone || m.Transition.Duration <= 0 {
+		m.finishTransition()
+	}
+	m.app.Redraw()
+}
+
+// Helper function ending a running transition
+func (m *SceneManager) finishTransition() {
+	if m.leaving != nil && m.exitAfter {
+		m.leaving.Exit()
+	}
+	m.leaving = nil
+}
+
+// Event passes an event to the top scene
+func (m *SceneManager) Event(event sdl.Event) {
+	if scene := m.Current(); scene != nil {
+		scene.Event(event)
+	}
+}
+
+// Update advances the transition and the top scene, returns true while frames are needed
+func (m *SceneManager) Update(dt float32) bool {
+	animating := false
+	if m.leaving != nil {
+		// Wall-clock time, so the transition ends on time however frames are spaced
+		m.progress = min(float32(sdl.GetTicksNS()-m.started)/1e9/m.Transition.Duration, 1)
+		if m.progress >= 1 {
+			m.finishTransition()
+		}
+		animating = true
+	}
+	if scene := m.Current(); scene != nil && scene.Update(dt) {
+		animating = true
+	}
+	return animating
+}
+
+// Render draws the top scene, during a transition blended with the leaving one
+func (m *SceneManager) Render(renderer *sdl.Renderer) {
+	scene := m.Current()
+	if m.leaving == nil {
+		if scene != nil {
+			scene.Render(renderer)
+		}
+		return
+	}
+
+	// The scene in front is drawn offscreen so it can be faded or moved as a whole
+	back, front := m.leaving, scene
+	if m.backwards {
+		back, front = scene, m.leaving // The popped scene leaves the way it came
+	}
+	if back != nil {
+		back.Render(renderer)
+	}
+	if front == nil || !m.target.Ensure(renderer, m.app.Width, m.app.Height) {
+		return
+	}
+	m.target.Begin(renderer)
+	front.Render(renderer)
+	m.target.End(renderer)
+
+	shown := m.progress // Share of the front scene that is visible
+	if m.backwards {
+		shown = 1 - m.progress
+	}
+	dst := sdl.FRect{W: m.app.Width, H: m.app.Height}
+	switch m.Transition.Kind {
+	case TransitionFade:
+		defer ui.PushOpacity(shown)()
+	case TransitionSlide:
+		dst.X = (1 - shown) * m.app.Width
+	}
+	src := sdl.FRect{W: m.app.Width, H: m.app.Height}
+	m.target.Draw(renderer, &src, &dst)
+}
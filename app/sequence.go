@@ -0,0 +1,96 @@
+// sequence.go
+package app
+
+// Sequences script multi-step flows (tutorials, cutscenes): do something,
+// wait, wait for a condition, continue. The main loop advances them every
+// frame, so every step runs on the main thread without goroutines.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Sequence is a list of steps run one after the other
+type Sequence struct {
+	steps    []sequenceStep
+	index    int    // Current step
+	started  uint64 // sdl.GetTicksNS time the current Wait step started (0 before)
+	canceled bool
+}
+
+// A step returns true when it is finished
+type sequenceStep func(s *Sequence, dt float32) bool
+
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// Do adds a step running fn
+func (s *Sequence) Do(fn func()) *Sequence {
+	return s.add(func(s *Sequence, dt float32) bool {
+		fn()
+		return true
+	})
+}
+
+// Wait adds a pause of seconds
+func (s *Sequence) Wait(seconds float32) *Sequence {
+	return s.add(func(s *Sequence, dt float32) bool {
+		// Wall-clock time, frames can be far apart while the loop waits for events
+		now := sdl.GetTicksNS()
+		if s.started == 0 {
+			s.started = now
+		}
+		return now-s.started >= secondsToNS(seconds)
+	})
+}
+
+// WaitUntil adds a step waiting until condition returns true (checked every frame)
+func (s *Sequence) WaitUntil(condition func() bool) *Sequence {
+	return s.add(func(s *Sequence, dt float32) bool {
+		return condition()
+	})
+}
+
+// Helper function appending a step
+func (s *Sequence) add(step sequenceStep) *Sequence {
+	s.steps = append(s.steps, step)
+	return s
+}
+
+// Tick runs the steps that can finish within this frame, returns true while
+// the sequence is still running
+func (s *Sequence) Tick(dt float32) bool {
+	for !s.Done() {
+		if !s.steps[s.index](s, dt) {
+			return true
+		}
+		s.index++
+		s.started = 0
+		dt = 0 // The frame time was used by the finished step
+	}
+	return false
+}
+
+// Cancel stops the sequence before its next step
+func (s *Sequence) Cancel() {
+	s.canceled = true
+}
+
+// Done returns true once every step ran or the sequence was canceled
+func (s *Sequence) Done() bool {
+	return s.canceled || s.index >= len(s.steps)
+}
+
+// Play advances seq every frame until it is done
+func (a *App) Play(seq *Sequence) *Sequence {
+	var remove func()
+	remove = a.OnTick(func(dt float32) bool {
+		if seq.Tick(dt) {
+			return true
+		}
+		remove()
+		return false
+	})
+	a.Redraw() // Start with the next frame instead of after an idle wait
+	return seq
+}
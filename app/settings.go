@@ -0,0 +1,137 @@
+// settings.go
+package app
+
+// Persistent application settings: typed values stored as JSON in the
+// user's preference directory, so options survive restarts. Changes are
+// published on the settings' own event bus under the key name.
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Settings holds key/value options loaded from and saved to Path
+type Settings struct {
+	Path    string
+	Changes *ui.EventBus // Publishes the key and new value of every change, subscribe to a key or "*"
+	values  map[string]any
+	changed bool // Unsaved changes
+}
+
+// SettingsPath returns the settings file of an application in the user's
+// preference directory (e.g. %APPDATA%\org\app on Windows, ~/.local/share/org/app on Linux)
+func SettingsPath(org, appName string) string {
+	dir := sdlext.GetPrefPath(org, appName)
+	if dir == "" {
+		dir = "." // No writable preference directory, keep settings next to the program
+	}
+	return filepath.Join(dir, "settings.json")
+}
+
+// LoadSettings reads the settings at path, a missing file gives empty settings
+func LoadSettings(path string) (*Settings, error) {
+	s := &Settings{Path: path, Changes: ui.NewEventBus(), values: make(map[string]any)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.values); err != nil {
+		return s, err
+	}
+	if s.values == nil { // The file held null
+		s.values = make(map[string]any)
+	}
+	return s, nil
+}
+
+// Save writes the settings if anything changed since they were loaded or saved
+func (s *Settings) Save() error {
+	if !s.changed {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.values, "", "\t")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash can't leave half a file
+	temp := s.Path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, s.Path); err != nil {
+		return err
+	}
+	s.changed = false
+	return nil
+}
+
+// Has reports whether key is set
+func (s *Settings) Has(key string) bool {
+	_, ok := s.values[key]
+	return ok
+}
+
+// String returns the string stored under key, or def if it is missing or not a string
+func (s *Settings) String(key, def string) string {
+	if value, ok := s.values[key].(string); ok {
+		return value
+	}
+	return def
+}
+
+// Float returns the number stored under key, or def
+func (s *Settings) Float(key string, def float64) float64 {
+	if value, ok := s.values[key].(float64); ok {
+		return value
+	}
+	return def
+}
+
+// Int returns the number stored under key as an int, or def
+func (s *Settings) Int(key string, def int) int {
+	if value, ok := s.values[key].(float64); ok {
+		return int(value)
+	}
+	return def
+}
+
+// Bool returns the boolean stored under key, or def
+func (s *Settings) Bool(key string, def bool) bool {
+	if value, ok := s.values[key].(bool); ok {
+		return value
+	}
+	return def
+}
+
+func (s *Settings) SetString(key, value string)        { s.set(key, value) }
+func (s *Settings) SetFloat(key string, value float64) { s.set(key, value) }
+func (s *Settings) SetInt(key string, value int)       { s.set(key, float64(value)) } // Stored like JSON numbers
+func (s *Settings) SetBool(key string, value bool)     { s.set(key, value) }
+
+// Delete removes key, getters return their default for it again
+func (s *Settings) Delete(key string) {
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.changed = true
+		s.Changes.Publish(key, nil)
+	}
+}
+
+// Helper function storing a value and publishing the change
+func (s *Settings) set(key string, value any) {
+	if old, ok := s.values[key]; ok && old == value {
+		return
+	}
+	s.values[key] = value
+	s.changed = true
+	s.Changes.Publish(key, value)
+}
@@ -0,0 +1,165 @@
+// shortcuts.go
+package app
+
+// Keyboard shortcuts: accelerators such as "Ctrl+Q" or "F11" bound to named
+// actions. The App dispatches them before OnEvent and the scenes, menus show
+// the accelerator of an action with ShortcutFor.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Shortcut is a key with modifiers (only Ctrl, Shift, Alt and Gui are kept)
+type Shortcut struct {
+	Key sdl.Keycode
+	Mod sdl.Keymod
+}
+
+// Modifier names in the order they are written
+var shortcutModifiers = []struct {
+	name string
+	mod  sdl.Keymod
+}{
+	{"Ctrl", sdl.KeymodCtrl},
+	{"Alt", sdl.KeymodAlt},
+	{"Shift", sdl.KeymodShift},
+	{"Gui", sdl.KeymodGui},
+}
+
+// ParseShortcut reads an accelerator like "Ctrl+Shift+Z", "Alt+F4" or "Escape"
+// (key names as in SDL_GetKeyName, case-insensitive)
+func ParseShortcut(accelerator string) (Shortcut, error) {
+	parts := strings.Split(accelerator, "+")
+	var shortcut Shortcut
+parts:
+	for _, part := range parts[:len(parts)-1] {
+		for _, modifier := range shortcutModifiers {
+			if strings.EqualFold(strings.TrimSpace(part), modifier.name) {
+				shortcut.Mod |= modifier.mod
+				continue parts
+			}
+		}
+		return Shortcut{}, fmt.Errorf("shortcut %q: unknown modifier %q", accelerator, part)
+	}
+	shortcut.Key = sdl.GetKeyFromName(strings.TrimSpace(parts[len(parts)-1]))
+	if shortcut.Key == sdl.KeycodeUnknown {
+		return Shortcut{}, fmt.Errorf("shortcut %q: unknown key", accelerator)
+	}
+	return shortcut, nil
+}
+
+// Helper function returning the shortcut of a key event
+func eventShortcut(key sdl.KeyboardEvent) Shortcut {
+	shortcut := Shortcut{Key: key.Key}
+	for _, modifier := range shortcutModifiers {
+		if key.Mod&modifier.mod != 0 {
+			shortcut.Mod |= modifier.mod
+		}
+	}
+	return shortcut
+}
+
+// String returns the accelerator as shown in menus, e.g. "Ctrl+Shift+Z"
+func (s Shortcut) String() string {
+	var text strings.Builder
+	for _, modifier := range shortcutModifiers {
+		if s.Mod&modifier.mod != 0 {
+			text.WriteString(modifier.name + "+")
+		}
+	}
+	text.WriteString(sdl.GetKeyName(s.Key))
+	return text.String()
+}
+
+// Helper function reporting whether a focused text field uses the shortcut
+// itself (typing, editing keys, clipboard and undo), so it is left to the widget
+func (s Shortcut) editsText() bool {
+	if s.Mod&(sdl.KeymodAlt|sdl.KeymodGui) != 0 {
+		return false
+	}
+	if s.Mod&sdl.KeymodCtrl != 0 {
+		switch s.Key {
+		case sdl.KeycodeA, sdl.KeycodeC, sdl.KeycodeV, sdl.KeycodeX, sdl.KeycodeZ, sdl.KeycodeY:
+			return true
+		}
+		return false
+	}
+	return s.Key < sdl.KeycodeF1 || s.Key > sdl.KeycodeF12 // Only function keys pass unmodified
+}
+
+// A shortcut bound to an action
+type shortcutBinding struct {
+	name   string
+	action func()
+}
+
+// ShortcutManager maps shortcuts to actions
+type ShortcutManager struct {
+	Window   *sdl.Window // Text input state is checked on it (set by the App)
+	bindings map[Shortcut]shortcutBinding
+}
+
+func NewShortcutManager() *ShortcutManager {
+	return &ShortcutManager{bindings: make(map[Shortcut]shortcutBinding)}
+}
+
+// Bind makes the accelerator run action, name describes it (e.g. "Undo").
+// Fails if the accelerator is invalid or already bound to another action.
+func (m *ShortcutManager) Bind(accelerator, name string, action func()) error {
+	shortcut, err := ParseShortcut(accelerator)
+	if err != nil {
+		return err
+	}
+	if bound, taken := m.bindings[shortcut]; taken {
+		return fmt.Errorf("shortcut %s is already bound to %q", shortcut, bound.name)
+	}
+	m.bindings[shortcut] = shortcutBinding{name: name, action: action}
+	return nil
+}
+
+// BindCommand makes the accelerator execute the command registered under
+// name (see RegisterCommand) through stack
+func (m *ShortcutManager) BindCommand(accelerator, name string, stack *CommandStack) error {
+	return m.Bind(accelerator, name, func() { stack.DoNamed(name) })
+}
+
+// Unbind removes the binding of an accelerator
+func (m *ShortcutManager) Unbind(accelerator string) {
+	if shortcut, err := ParseShortcut(accelerator); err == nil {
+		delete(m.bindings, shortcut)
+	}
+}
+
+// ShortcutFor returns the accelerator bound to the named action (for menus), or ""
+func (m *ShortcutManager) ShortcutFor(name string) string {
+	found := ""
+	for shortcut, binding := range m.bindings {
+		// Several shortcuts may share an action, show the shortest
+		text := shortcut.String()
+		if binding.name == name && (found == "" || len(text) < len(found) || len(text) == len(found) && text < found) {
+			found = text
+		}
+	}
+	return found
+}
+
+// HandleEvent runs the action bound to a key press, returns true if there was one.
+// While a text field is focused it keeps the keys it uses for editing.
+func (m *ShortcutManager) HandleEvent(event sdl.Event) bool {
+	if event.Type() != sdl.EventKeyDown || event.Key().Repeat {
+		return false // Held keys run the action once
+	}
+	shortcut := eventShortcut(event.Key())
+	binding, ok := m.bindings[shortcut]
+	if !ok {
+		return false
+	}
+	if m.Window != nil && sdl.TextInputActive(m.Window) && shortcut.editsText() {
+		return false
+	}
+	binding.action()
+	return true
+}
@@ -0,0 +1,147 @@
+// statemachine.go
+package app
+
+// Hierarchical state machine for UI modes ("normal", "dragging", "alert
+// shown"...): one current state, allowed transitions between states and
+// enter/exit hooks. States may have a parent, a state is "in" its parents
+// too and transitions allowed from a parent apply to its children.
+
+import (
+	"fmt"
+)
+
+// State is a mode of the state machine
+type State struct {
+	Name    string
+	Parent  *State
+	OnEnter func()
+	OnExit  func()
+}
+
+// StateMachine holds the states and the current one
+type StateMachine struct {
+	OnChange    func(from, to string) // After every transition
+	states      map[string]*State
+	transitions map[string]map[string]bool // From, to
+	current     *State
+}
+
+func NewStateMachine() *StateMachine {
+	return &StateMachine{states: make(map[string]*State), transitions: make(map[string]map[string]bool)}
+}
+
+// Add defines a state, parent is "" for top-level states and must be added first
+func (m *StateMachine) Add(name, parent string, onEnter, onExit func()) *State {
+	state := &State{Name: name, OnEnter: onEnter, OnExit: onExit}
+	if parent != "" {
+		state.Parent = m.mustGet(parent)
+	}
+	m.states[name] = state
+	return state
+}
+
+// Allow permits transitions from a state (or any of its children) to each of the to states
+func (m *StateMachine) Allow(from string, to ...string) {
+	m.mustGet(from)
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[string]bool)
+	}
+	for _, name := range to {
+		m.mustGet(name)
+		m.transitions[from][name] = true
+	}
+}
+
+// Start enters the initial state (and its parents) without checking transitions
+func (m *StateMachine) Start(name string) {
+	m.current = nil
+	m.enter(nil, m.mustGet(name))
+}
+
+// Current returns the name of the current state
+func (m *StateMachine) Current() string {
+	if m.current == nil {
+		return ""
+	}
+	return m.current.Name
+}
+
+// In reports whether the current state is name or one of its children
+func (m *StateMachine) In(name string) bool {
+	for state := m.current; state != nil; state = state.Parent {
+		if state.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CanGo reports whether the transition to name is allowed from the current state
+func (m *StateMachine) CanGo(name string) bool {
+	for state := m.current; state != nil; state = state.Parent {
+		if m.transitions[state.Name][name] {
+			return true
+		}
+	}
+	return false
+}
+
+// Go switches to the named state if the transition is allowed, returns
+// false otherwise. States are exited up to the common parent, then the new
+// ones are entered.
+func (m *StateMachine) Go(name string) bool {
+	if !m.CanGo(name) {
+		return false
+	}
+	from, to := m.current, m.states[name]
+	common := commonAncestor(from, to)
+	for state := from; state != common; state = state.Parent {
+		if state.OnExit != nil {
+			state.OnExit()
+		}
+	}
+	m.enter(common, to)
+	if m.OnChange != nil {
+		m.OnChange(from.Name, to.Name)
+	}
+	return true
+}
+
+// Helper function entering the states from below common down to target
+func (m *StateMachine) enter(common, target *State) {
+	var path []*State
+	for state := target; state != common; state = state.Parent {
+		path = append(path, state)
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].OnEnter != nil {
+			path[i].OnEnter()
+		}
+	}
+	m.current = target
+}
+
+// Helper function returning the deepest state both a and b are in (nil if
+// none). A transition to the current state itself exits and enters it again.
+func commonAncestor(a, b *State) *State {
+	if a == b {
+		return a.Parent
+	}
+	for x := a; x != nil; x = x.Parent {
+		for y := b; y != nil; y = y.Parent {
+			if x == y {
+				return x
+			}
+		}
+	}
+	return nil
+}
+
+// Helper function returning a state, panicking on names that were never added
+func (m *StateMachine) mustGet(name string) *State {
+	state, ok := m.states[name]
+	if !ok {
+		panic(fmt.Sprintf("state machine: unknown state %q", name))
+	}
+	return state
+}
@@ -0,0 +1,103 @@
+// tasks.go
+package app
+
+// Background tasks: slow work (network, disk) runs in a goroutine so the
+// loop stays responsive. Progress and the result are delivered on the main
+// thread, where widgets can be changed.
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Task is work running in the background, its fields belong to the main thread
+type Task struct {
+	Progress   float32                // Last reported progress (0 to 1)
+	Bar        *ui.ProgressBar        // Shows Progress when set
+	OnProgress func(progress float32) // Called on the main thread when the progress changes
+	app        *App
+	cancel     context.CancelFunc
+	done       bool
+	reported   atomic.Uint32 // Latest progress from the worker (float bits)
+	queued     atomic.Bool   // A progress update is waiting for the main thread
+}
+
+// StartTask runs work in a goroutine and calls onDone (may be nil) with its
+// result on the main thread. Work should check ctx to stop early when the task
+// is canceled (onDone then gets ctx.Err()), report is safe to call often.
+func StartTask[T any](a *App, work func(ctx context.Context, report func(progress float32)) (T, error), onDone func(result T, err error)) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &Task{app: a, cancel: cancel}
+	a.tasks[task] = struct{}{}
+	go func() {
+		result, err := work(ctx, task.report)
+		if ctx.Err() != nil {
+			err = ctx.Err() // Canceled results are not used
+		}
+		a.RunOnMainThread(func() {
+			task.finish(err == nil)
+			if onDone != nil {
+				onDone(result, err)
+			}
+		})
+	}()
+	return task
+}
+
+// Cancel asks the work to stop, onDone still runs once it returns
+func (t *Task) Cancel() {
+	t.cancel()
+}
+
+// Done returns true once the result was delivered
+func (t *Task) Done() bool {
+	return t.done
+}
+
+// Helper function called by the worker to report progress, coalesced so a
+// busy worker queues at most one update at a time
+func (t *Task) report(progress float32) {
+	t.reported.Store(math.Float32bits(progress))
+	if t.queued.Swap(true) {
+		return
+	}
+	t.app.RunOnMainThread(func() {
+		t.queued.Store(false)
+		t.setProgress(math.Float32frombits(t.reported.Load()))
+	})
+}
+
+// Helper function updating the progress on the main thread
+func (t *Task) setProgress(progress float32) {
+	if t.done || progress == t.Progress {
+		return
+	}
+	t.Progress = progress
+	if t.Bar != nil {
+		t.Bar.SetValue(progress)
+	}
+	if t.OnProgress != nil {
+		t.OnProgress(progress)
+	}
+}
+
+// Helper function marking the task finished on the main thread, a failed or
+// canceled task keeps its last progress
+func (t *Task) finish(succeeded bool) {
+	if succeeded {
+		t.setProgress(1)
+	}
+	t.done = true
+	t.cancel() // Releases the context
+	delete(t.app.tasks, t)
+}
+
+// Helper function canceling the tasks still running when the loop ends
+func (a *App) cancelTasks() {
+	for task := range a.tasks {
+		task.cancel()
+	}
+}
@@ -0,0 +1,70 @@
+// theme.go
+package app
+
+// Light and dark theme: the application draws with the colors of the current
+// Theme, which can follow the system setting (Config.FollowSystemTheme) and
+// switches when the user changes it.
+
+import (
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Theme is the color scheme of the application
+type Theme int
+
+const (
+	ThemeDark Theme = iota
+	ThemeLight
+)
+
+func (t Theme) String() string {
+	if t == ThemeLight {
+		return "light"
+	}
+	return "dark"
+}
+
+// SystemTheme returns the theme the system uses, ok is false if it doesn't say
+func SystemTheme() (theme Theme, ok bool) {
+	switch sdlext.GetSystemTheme() {
+	case sdl.SystemThemeLight:
+		return ThemeLight, true
+	case sdl.SystemThemeDark:
+		return ThemeDark, true
+	}
+	return ThemeDark, false
+}
+
+// Theme returns the current theme
+func (a *App) Theme() Theme {
+	return a.theme
+}
+
+// SetTheme switches the theme, cached drawings are redone and OnThemeChanged runs
+func (a *App) SetTheme(theme Theme) {
+	if theme == a.theme {
+		return
+	}
+	a.theme = theme
+	slog.Info("theme changed", "theme", theme)
+	ui.InvalidateRenderCaches()
+	a.needsRedraw = true
+	if a.OnThemeChanged != nil {
+		a.OnThemeChanged(theme)
+	}
+}
+
+// Helper function switching to the system theme when following it
+func (a *App) followSystemTheme() {
+	if !a.Config.FollowSystemTheme {
+		return
+	}
+	if theme, ok := SystemTheme(); ok {
+		a.SetTheme(theme)
+	}
+}
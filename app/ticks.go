@@ -0,0 +1,38 @@
+// ticks.go
+package app
+
+// Per-frame callbacks: subsystems (animations, toasts, caret blinking)
+// register their own tick function instead of growing OnUpdate.
+
+// A registered tick callback (compared by pointer to remove it)
+type tickCallback struct {
+	tick func(dt float32) bool
+}
+
+// OnTick calls tick every loop iteration after OnUpdate, in registration
+// order. Tick returns true while it needs frames (like Scene.Update).
+// Returns a function that removes the callback.
+func (a *App) OnTick(tick func(dt float32) bool) func() {
+	callback := &tickCallback{tick: tick}
+	a.ticks = append(a.ticks, callback)
+	return func() {
+		for i, c := range a.ticks {
+			if c == callback {
+				a.ticks = append(a.ticks[:i:i], a.ticks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Helper function running the tick callbacks, returns true if any needs frames
+func (a *App) runTicks(dt float32) bool {
+	animating := false
+	// Iterate over a snapshot so callbacks may add or remove callbacks
+	for _, callback := range a.ticks[:len(a.ticks):len(a.ticks)] {
+		if callback.tick(dt) {
+			animating = true
+		}
+	}
+	return animating
+}
@@ -0,0 +1,92 @@
+// timers.go
+package app
+
+// Timers run a function on the main thread after a delay (After) or
+// repeatedly (Every). They follow the wall clock, so they also fire while
+// the loop idles, which is woken up in time for the next one.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Timer is a scheduled function
+type Timer struct {
+	due      uint64 // sdl.GetTicksNS time of the next run
+	interval uint64 // 0 for one-shot timers
+	fn       func()
+	stopped  bool
+}
+
+// After runs fn once after seconds, e.g. to show a tooltip
+func (a *App) After(seconds float32, fn func()) *Timer {
+	return a.addTimer(&Timer{due: sdl.GetTicksNS() + secondsToNS(seconds), fn: fn})
+}
+
+// Every runs fn every seconds until the timer is canceled, e.g. for autosave.
+// A loop that fell behind runs it once, not once per missed interval.
+func (a *App) Every(seconds float32, fn func()) *Timer {
+	interval := max(secondsToNS(seconds), 1)
+	return a.addTimer(&Timer{due: sdl.GetTicksNS() + interval, interval: interval, fn: fn})
+}
+
+// Cancel stops the timer, fn won't run again
+func (t *Timer) Cancel() {
+	t.stopped = true
+}
+
+// Helper function converting seconds to nanoseconds
+func secondsToNS(seconds float32) uint64 {
+	return uint64(max(seconds, 0) * 1e9)
+}
+
+// Helper function adding a timer
+func (a *App) addTimer(t *Timer) *Timer {
+	a.timers = append(a.timers, t)
+	return t
+}
+
+// Helper function running the due timers, returns true if any ran
+func (a *App) runTimers() bool {
+	ran := false
+	now := sdl.GetTicksNS()
+	for _, t := range a.timers[:len(a.timers):len(a.timers)] { // Timer functions may add timers
+		if t.stopped || t.due > now {
+			continue
+		}
+		if t.interval == 0 {
+			t.stopped = true
+		} else {
+			t.due = max(t.due+t.interval, now+1) // Skip missed runs
+		}
+		t.fn()
+		ran = true
+	}
+
+	// Drop finished timers
+	active := a.timers[:0]
+	for _, t := range a.timers {
+		if !t.stopped {
+			active = append(active, t)
+		}
+	}
+	clear(a.timers[len(active):])
+	a.timers = active
+	return ran
+}
+
+// Helper function returning how long an idle loop may wait for events
+// before the next timer is due (milliseconds, at most idleWaitMS)
+func (a *App) idleWait() int32 {
+	wait := uint64(idleWaitMS)
+	now := sdl.GetTicksNS()
+	for _, t := range a.timers {
+		if t.stopped {
+			continue
+		}
+		if t.due <= now {
+			return 0
+		}
+		wait = min(wait, (t.due-now+999_999)/1_000_000) // Round up, waking early would just wait again
+	}
+	return int32(wait)
+}
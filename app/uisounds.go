@@ -0,0 +1,60 @@
+// uisounds.go
+package app
+
+// Feedback sounds of the UI (button clicks, toasts, dialogs, errors), one set
+// per theme, and the global mute kept in the settings.
+
+import (
+	"arkenidar.com/purego-sdl3/audio"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Volume of the feedback sounds, under other sounds like music
+const uiSoundVolume = 0.5
+
+// UISounds are the feedback sounds of one theme, kinds without a sound are silent
+type UISounds map[ui.UISound]*audio.Sound
+
+// DefaultUISounds returns generated sounds for a theme: the light theme
+// sounds brighter, the dark one softer and lower
+func DefaultUISounds(theme Theme) UISounds {
+	pitch := float32(1)
+	if theme == ThemeDark {
+		pitch = 0.75
+	}
+	return UISounds{
+		ui.SoundClick:        audio.Tone(0.04, 1400*pitch),
+		ui.SoundToggle:       audio.Tone(0.06, 900*pitch, 1200*pitch),
+		ui.SoundError:        audio.Tone(0.12, 440*pitch, 330*pitch),
+		ui.SoundNotification: audio.Tone(0.15, 880*pitch, 1320*pitch),
+	}
+}
+
+// Helper function playing a feedback sound of the current theme (the
+// ui.SoundPlayer while running)
+func (a *App) playUISound(kind ui.UISound) {
+	if a.Audio.Muted() {
+		return
+	}
+	if sound := a.ThemeSounds[a.Theme()][kind]; sound != nil {
+		a.Audio.Play(sound, uiSoundVolume)
+	}
+}
+
+// Muted reports whether all sound is off
+func (a *App) Muted() bool {
+	return a.Audio.Muted()
+}
+
+// SetMuted turns all sound off or on again, remembered in the settings
+func (a *App) SetMuted(muted bool) {
+	a.Audio.SetMuted(muted)
+	if a.Settings != nil {
+		a.Settings.SetBool("audio.muted", muted)
+	}
+}
+
+// ToggleMuted turns all sound off or on again
+func (a *App) ToggleMuted() {
+	a.SetMuted(!a.Muted())
+}
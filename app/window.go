@@ -0,0 +1,179 @@
+// window.go
+package app
+
+// Window appearance: the icon shown in the title bar and task bar, (desktop)
+// fullscreen, toggled with F11 unless the application unbinds it, the window
+// style of overlays and tool windows, limits of resizing and the hit test of
+// custom title bars.
+
+import (
+	"fmt"
+	"log/slog"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// SetIcon shows the image asset name (BMP, PNG or JPEG, e.g. 64x64 or larger)
+// as the window icon. Config.Icon is set this way when the window opens.
+func (a *App) SetIcon(name string) error {
+	data, err := a.Assets.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	icon := ui.SurfaceFromData(name, data)
+	if icon == nil {
+		return fmt.Errorf("icon %q: %s", name, sdl.GetError())
+	}
+	defer sdl.DestroySurface(icon) // SDL keeps a copy
+	if !sdl.SetWindowIcon(a.Window, icon) {
+		return fmt.Errorf("icon %q: %s", name, sdl.GetError())
+	}
+	return nil
+}
+
+// Fullscreen reports whether the window covers its display
+func (a *App) Fullscreen() bool {
+	return sdlext.GetWindowFlags(a.Window)&sdl.WindowFullscreen != 0
+}
+
+// SetFullscreen switches between desktop fullscreen (the display keeps its
+// resolution) and the normal window. The resize event that follows updates
+// Width and Height, relayout on sdl.EventWindowResized.
+func (a *App) SetFullscreen(fullscreen bool) {
+	if !sdl.SetWindowFullscreen(a.Window, fullscreen) {
+		slog.Warn("fullscreen not changed", "error", sdl.GetError())
+		return
+	}
+	sdl.SyncWindow(a.Window) // Resized before the next frame is drawn
+}
+
+// ToggleFullscreen enters or leaves fullscreen
+func (a *App) ToggleFullscreen() {
+	a.SetFullscreen(!a.Fullscreen())
+}
+
+// Helper function returning the window flags of the configured style
+func (c *Config) windowFlags() sdl.WindowFlags {
+	var flags sdl.WindowFlags
+	for _, style := range []struct {
+		enabled bool
+		flag    sdl.WindowFlags
+	}{
+		{c.Fullscreen, sdl.WindowFullscreen},
+		{c.Borderless, sdl.WindowBorderless},
+		{c.AlwaysOnTop, sdl.WindowAlwaysOnTop},
+		{c.Transparent, sdl.WindowTransparent},
+		{c.Utility, sdl.WindowUtility},
+	} {
+		if style.enabled {
+			flags |= style.flag
+		}
+	}
+	return flags
+}
+
+// SetBorderless removes or restores the title bar and frame
+func (a *App) SetBorderless(borderless bool) {
+	if !sdl.SetWindowBordered(a.Window, !borderless) {
+		slog.Warn("window border not changed", "error", sdl.GetError())
+	}
+}
+
+// SetAlwaysOnTop keeps the window above other windows, or not
+func (a *App) SetAlwaysOnTop(onTop bool) {
+	if !sdl.SetWindowAlwaysOnTop(a.Window, onTop) {
+		slog.Warn("always on top not changed", "error", sdl.GetError())
+	}
+}
+
+// SetSizeLimits keeps the window between the minimum and maximum size while
+// the user resizes it (0 for no limit)
+func (a *App) SetSizeLimits(minWidth, minHeight, maxWidth, maxHeight int32) {
+	if !sdlext.SetWindowMinimumSize(a.Window, minWidth, minHeight) || !sdlext.SetWindowMaximumSize(a.Window, maxWidth, maxHeight) {
+		slog.Warn("window size limits not set", "error", sdl.GetError())
+	}
+}
+
+// SetAspectRatio keeps width/height at aspect while the user resizes the
+// window, 0 lets it change freely
+func (a *App) SetAspectRatio(aspect float32) {
+	if !sdlext.SetWindowAspectRatio(a.Window, aspect, aspect) {
+		slog.Warn("window aspect ratio not set", "error", sdl.GetError())
+	}
+}
+
+// Helper function applying the configured size limits to the new window
+func (a *App) applySizeLimits() {
+	c := a.Config
+	if c.MinWidth > 0 || c.MinHeight > 0 || c.MaxWidth > 0 || c.MaxHeight > 0 {
+		a.SetSizeLimits(c.MinWidth, c.MinHeight, c.MaxWidth, c.MaxHeight)
+	}
+	if c.Aspect > 0 {
+		a.SetAspectRatio(c.Aspect)
+	}
+}
+
+// Width of the resize border around borderless windows, in logical units
+const resizeBorder = 6
+
+// SetHitTest lets a borderless window with an in-app title bar (see
+// ui.TitleBar) be moved and resized: hit returns sdl.HitTestDraggable where
+// dragging moves the window, e.g. TitleBar.HitTest. Borderless windows also
+// get resize handles along their edges. nil removes the hit test.
+func (a *App) SetHitTest(hit func(x, y float32) sdl.HitTestResult) {
+	a.hitTest = hit
+	if a.hitTestSet {
+		return // Callbacks are never freed, install one for the whole run
+	}
+	if !sdl.SetWindowHitTest(a.Window, a.windowHitTest, nil) {
+		slog.Warn("window hit test not set", "error", sdl.GetError())
+		return
+	}
+	a.hitTestSet = true
+}
+
+// Helper function answering SDL's hit test, point is in window coordinates
+func (a *App) windowHitTest(window *sdl.Window, point *sdl.Point, data unsafe.Pointer) sdl.HitTestResult {
+	if a.hitTest == nil {
+		return sdl.HitTestNormal
+	}
+	x, y := float32(point.X), float32(point.Y)
+	flags := sdlext.GetWindowFlags(window)
+	if flags&sdl.WindowBorderless != 0 && flags&sdl.WindowResizable != 0 &&
+		flags&(sdl.WindowMaximized|sdl.WindowFullscreen) == 0 {
+		if edge := resizeEdge(x, y, a.Width, a.Height); edge != sdl.HitTestNormal {
+			return edge
+		}
+	}
+	return a.hitTest(x, y)
+}
+
+// Helper function returning the resize handle at x, y of a window w by h
+// units, sdl.HitTestNormal away from the edges
+func resizeEdge(x, y, w, h float32) sdl.HitTestResult {
+	left, right := x < resizeBorder, x >= w-resizeBorder
+	top, bottom := y < resizeBorder, y >= h-resizeBorder
+	switch {
+	case top && left:
+		return sdl.HitTestResizeTopLeft
+	case top && right:
+		return sdl.HitTestResizeTopRight
+	case bottom && left:
+		return sdl.HitTestResizeBottomLeft
+	case bottom && right:
+		return sdl.HitTestResizeBottomRight
+	case top:
+		return sdl.HitTestResizeTop
+	case bottom:
+		return sdl.HitTestResizeBottom
+	case left:
+		return sdl.HitTestResizeLeft
+	case right:
+		return sdl.HitTestResizeRight
+	}
+	return sdl.HitTestNormal
+}
@@ -0,0 +1,12 @@
+// assets.go
+
+// Package assets holds the files the framework ships with (the default UI
+// font and window icon), built into programs so they run from any directory.
+package assets
+
+import (
+	"embed"
+)
+
+//go:embed OpenDyslexic-Regular.ttf icon.png
+var FS embed.FS
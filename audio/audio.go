@@ -0,0 +1,186 @@
+// audio.go
+
+// Package audio plays sounds on top of SDL3 audio streams: a Device mixes
+// any number of voices, each a stream converting its sound to the device
+// format, and music streamed from files.
+package audio
+
+// Playback device: opened once (the App does it), with a master volume and
+// the voices currently playing.
+
+import (
+	"errors"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// Device is an opened audio output. A Device that failed to open stays
+// usable and plays nothing, so callers need no checks.
+type Device struct {
+	ID     sdl.AudioDeviceID // 0 when no device could be opened
+	Spec   sdl.AudioSpec     // Format the device mixes in
+	voices map[*Voice]struct{}
+	music  map[*Music]struct{} // Opened with OpenMusic, until closed
+	volume float32             // Master volume, kept while muted
+	muted  bool
+}
+
+// Open opens the default playback device, the audio subsystem must be initialized
+func Open() (*Device, error) {
+	d := Silent()
+	id := sdlext.OpenAudioDevice(sdl.AudioDeviceDefaultPlayback, nil)
+	if id == 0 {
+		return d, errors.New(sdl.GetError())
+	}
+	d.ID = id
+	sdlext.GetAudioDeviceFormat(id, &d.Spec, nil)
+	return d, nil
+}
+
+// Silent returns a device playing nothing, e.g. when there is no audio hardware
+func Silent() *Device {
+	return &Device{voices: make(map[*Voice]struct{}), music: make(map[*Music]struct{}), volume: 1}
+}
+
+// Available reports whether the device plays sound
+func (d *Device) Available() bool {
+	return d.ID != 0
+}
+
+// Volume returns the master volume, 1 is unchanged
+func (d *Device) Volume() float32 {
+	return d.volume
+}
+
+// SetVolume scales everything the device plays, 0 is silent and above 1
+// amplifies. A muted device keeps the volume for when it is unmuted.
+func (d *Device) SetVolume(volume float32) {
+	d.volume = max(volume, 0)
+	d.applyGain()
+}
+
+// Muted reports whether the device is silenced
+func (d *Device) Muted() bool {
+	return d.muted
+}
+
+// SetMuted silences everything or restores the volume, voices keep playing
+func (d *Device) SetMuted(muted bool) {
+	d.muted = muted
+	d.applyGain()
+}
+
+// Helper function applying the volume and mute state to the device
+func (d *Device) applyGain() {
+	if d.ID == 0 {
+		return
+	}
+	gain := d.volume
+	if d.muted {
+		gain = 0
+	}
+	sdlext.SetAudioDeviceGain(d.ID, gain)
+}
+
+// Pause stops all voices where they are, e.g. while the app is in the background
+func (d *Device) Pause() {
+	if d.ID != 0 {
+		sdlext.PauseAudioDevice(d.ID)
+	}
+}
+
+// Resume continues the voices after Pause
+func (d *Device) Resume() {
+	if d.ID != 0 {
+		sdlext.ResumeAudioDevice(d.ID)
+	}
+}
+
+// Play starts playing sound at volume (1 is unchanged) and returns its voice,
+// nil if the device is not available. Sounds may overlap, each plays once.
+func (d *Device) Play(sound *Sound, volume float32) *Voice {
+	if d.ID == 0 || len(sound.Data) == 0 {
+		return nil
+	}
+	stream := sdlext.CreateAudioStream(&sound.Spec, &d.Spec)
+	if stream == nil {
+		return nil
+	}
+	voice := &Voice{stream: stream}
+	voice.SetVolume(volume)
+	// The stream copies the data, then flushes so the end is converted too
+	sdl.PutAudioStreamData(stream, &sound.Data[0], int32(len(sound.Data)))
+	sdl.FlushAudioStream(stream)
+	if !sdlext.BindAudioStream(d.ID, stream) {
+		sdl.DestroyAudioStream(stream)
+		return nil
+	}
+	d.voices[voice] = struct{}{}
+	return voice
+}
+
+// Playing returns the number of voices still playing
+func (d *Device) Playing() int {
+	return len(d.voices)
+}
+
+// Update frees the voices that finished and advances music fades, call it
+// every frame (the App does)
+func (d *Device) Update() {
+	for music := range d.music {
+		music.update()
+	}
+	for voice := range d.voices {
+		if !voice.Playing() {
+			voice.Stop()
+			delete(d.voices, voice)
+		}
+	}
+}
+
+// StopAll stops every voice
+func (d *Device) StopAll() {
+	for voice := range d.voices {
+		voice.Stop()
+	}
+	clear(d.voices)
+}
+
+// Close stops the voices, closes the music and closes the device
+func (d *Device) Close() {
+	d.StopAll()
+	for music := range d.music {
+		music.Close()
+	}
+	if d.ID != 0 {
+		sdlext.CloseAudioDevice(d.ID)
+		d.ID = 0
+	}
+}
+
+// Voice is one playing sound
+type Voice struct {
+	stream *sdl.AudioStream // nil once stopped
+}
+
+// Playing reports whether the voice has data left to play
+func (v *Voice) Playing() bool {
+	return v != nil && v.stream != nil && sdlext.GetAudioStreamAvailable(v.stream) > 0
+}
+
+// SetVolume changes the voice's volume, 1 is unchanged
+func (v *Voice) SetVolume(volume float32) {
+	if v != nil && v.stream != nil {
+		sdlext.SetAudioStreamGain(v.stream, max(volume, 0))
+	}
+}
+
+// Stop ends the voice right away
+func (v *Voice) Stop() {
+	if v != nil && v.stream != nil {
+		sdl.DestroyAudioStream(v.stream) // Also unbinds it
+		v.stream = nil
+	}
+}
@@ -0,0 +1,262 @@
+// music.go
+package audio
+
+// Streamed music: a WAV file is read by a goroutine a little ahead of
+// playback instead of being loaded whole, so long tracks take little memory.
+// Music can loop and fade in and out, the fades advance in Device.Update.
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+const (
+	musicAhead = 0.5                   // Seconds of samples kept queued
+	musicPoll  = 20 * time.Millisecond // How often the goroutine tops the queue up
+)
+
+// Music is a streamed track, created with Device.OpenMusic. On a device that
+// is not available it plays nothing.
+type Music struct {
+	device  *Device
+	stream  *sdl.AudioStream // nil without a device
+	source  *wavReader       // Used by the goroutine while it runs
+	closer  io.Closer        // The file, if it needs closing
+	loop    atomic.Bool
+	stop    chan struct{} // Closed to end the goroutine
+	done    chan struct{} // Closed when the goroutine ended
+	bound   bool          // Playing on the device (not paused)
+	volume  float32
+	fade    musicFade
+	playing bool // Started and not stopped
+}
+
+// Volume change in progress
+type musicFade struct {
+	from, to float32
+	start    time.Time
+	duration time.Duration
+	stop     bool // Stop the music when the fade ends
+	active   bool
+}
+
+// OpenMusic prepares the WAV data read from r for playback. r is closed by
+// Music.Close when it is an io.Closer, e.g. a file.
+func (d *Device) OpenMusic(r io.ReadSeeker) (*Music, error) {
+	source, err := newWAVReader(r)
+	if err != nil {
+		return nil, err
+	}
+	m := &Music{device: d, source: source, volume: 1}
+	m.closer, _ = r.(io.Closer)
+	if d.ID != 0 {
+		m.stream = sdlext.CreateAudioStream(&source.spec, &d.Spec)
+		if m.stream == nil {
+			return nil, errors.New(sdl.GetError())
+		}
+	}
+	d.music[m] = struct{}{}
+	return m, nil
+}
+
+// SetLooping makes the track start over at its end (safe to change while playing)
+func (m *Music) SetLooping(loop bool) {
+	m.loop.Store(loop)
+}
+
+// Playing reports whether the track is playing or paused, false once it
+// ended or was stopped
+func (m *Music) Playing() bool {
+	return m.playing
+}
+
+// Paused reports whether the track is paused
+func (m *Music) Paused() bool {
+	return m.playing && !m.bound
+}
+
+// Play starts the track from the beginning, or continues it when paused
+func (m *Music) Play() {
+	if m.stream == nil {
+		return
+	}
+	m.fade.active = false
+	m.applyGain(1)
+	if m.playing {
+		m.Resume()
+		return
+	}
+	sdl.ClearAudioStream(m.stream)
+	if err := m.source.Rewind(); err != nil {
+		slog.Warn("music not rewound", "error", err)
+		return
+	}
+	m.stop, m.done = make(chan struct{}), make(chan struct{})
+	go m.feed(m.stop, m.done)
+	m.playing = true
+	m.Resume()
+}
+
+// Pause holds the track where it is
+func (m *Music) Pause() {
+	if m.stream != nil && m.bound {
+		sdlext.UnbindAudioStream(m.stream)
+		m.bound = false
+	}
+}
+
+// Resume continues the track after Pause
+func (m *Music) Resume() {
+	if m.stream == nil || m.bound || !m.playing {
+		return
+	}
+	if !sdlext.BindAudioStream(m.device.ID, m.stream) {
+		slog.Warn("music not resumed", "error", sdl.GetError())
+		return
+	}
+	m.bound = true
+}
+
+// Stop ends the track, Play starts it from the beginning again
+func (m *Music) Stop() {
+	if m.stream == nil || !m.playing {
+		return
+	}
+	close(m.stop)
+	<-m.done // The goroutine no longer reads the file
+	m.Pause()
+	sdl.ClearAudioStream(m.stream)
+	m.fade.active = false
+	m.playing = false
+}
+
+// Volume returns the track's volume, 1 is unchanged
+func (m *Music) Volume() float32 {
+	return m.volume
+}
+
+// SetVolume changes the track's volume (under the device's master volume)
+func (m *Music) SetVolume(volume float32) {
+	m.volume = max(volume, 0)
+	if !m.fade.active {
+		m.applyGain(1)
+	}
+}
+
+// FadeIn starts the track from silence, reaching its volume after seconds
+func (m *Music) FadeIn(seconds float32) {
+	m.Play()
+	m.startFade(0, 1, seconds, false)
+}
+
+// FadeOut lowers the volume to silence over seconds, then stops the track
+func (m *Music) FadeOut(seconds float32) {
+	if !m.playing {
+		return
+	}
+	m.startFade(m.level(), 0, seconds, true)
+}
+
+// Close stops the track and closes its file
+func (m *Music) Close() {
+	m.Stop()
+	if m.stream != nil {
+		sdl.DestroyAudioStream(m.stream)
+		m.stream = nil
+	}
+	if m.closer != nil {
+		m.closer.Close()
+	}
+	delete(m.device.music, m)
+}
+
+// Helper function starting a fade between two levels (fractions of the volume)
+func (m *Music) startFade(from, to, seconds float32, stop bool) {
+	m.fade = musicFade{from: from, to: to, start: time.Now(), duration: time.Duration(seconds * float32(time.Second)), stop: stop, active: true}
+	m.applyGain(from)
+}
+
+// Helper function returning the current fraction of the volume
+func (m *Music) level() float32 {
+	if !m.fade.active {
+		return 1
+	}
+	if m.fade.duration <= 0 {
+		return m.fade.to
+	}
+	t := min(float32(time.Since(m.fade.start))/float32(m.fade.duration), 1)
+	return m.fade.from + (m.fade.to-m.fade.from)*t
+}
+
+// Helper function applying the volume at a level to the stream
+func (m *Music) applyGain(level float32) {
+	if m.stream != nil {
+		sdlext.SetAudioStreamGain(m.stream, m.volume*level)
+	}
+}
+
+// Helper function advancing the fade and noticing the end of the track,
+// called by Device.Update
+func (m *Music) update() {
+	if m.fade.active {
+		level := m.level()
+		m.applyGain(level)
+		if time.Since(m.fade.start) >= m.fade.duration {
+			m.fade.active = false
+			if m.fade.stop {
+				m.Stop()
+			}
+		}
+	}
+	if !m.playing {
+		return
+	}
+	select {
+	case <-m.done:
+		// Everything was read, the track ends once the device played it
+		if sdlext.GetAudioStreamAvailable(m.stream) <= 0 {
+			m.Stop()
+		}
+	default:
+	}
+}
+
+// Helper function run by the goroutine: keeps musicAhead seconds queued until
+// stop is closed or the track ended (and doesn't loop)
+func (m *Music) feed(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ahead := int32(float32(m.source.bytesPerSecond()) * musicAhead)
+	buffer := make([]byte, max(ahead/4/int32(m.source.frameSize), 1)*int32(m.source.frameSize))
+	ticker := time.NewTicker(musicPoll)
+	defer ticker.Stop()
+	for {
+		for sdl.GetAudioStreamQueued(m.stream) < ahead {
+			n, err := m.source.Read(buffer)
+			if n > 0 {
+				sdl.PutAudioStreamData(m.stream, &buffer[0], int32(n))
+			}
+			if err == io.EOF && m.loop.Load() && m.source.position > 0 {
+				err = m.source.Rewind()
+			}
+			if err != nil {
+				if err != io.EOF {
+					slog.Warn("music not read to the end", "error", err)
+				}
+				sdl.FlushAudioStream(m.stream) // The last samples are played too
+				return
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
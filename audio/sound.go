@@ -0,0 +1,48 @@
+// sound.go
+package audio
+
+// Sounds: decoded sample data kept in memory, loaded from WAV files (by SDL)
+// or generated.
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Sound is decoded audio ready to be played
+type Sound struct {
+	Spec sdl.AudioSpec // Format of Data
+	Data []byte        // Interleaved samples
+}
+
+// LoadWAV decodes the contents of a WAV file (e.g. from AssetManager.Sound)
+func LoadWAV(data []byte) (*Sound, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty WAV data")
+	}
+	sound := &Sound{}
+	var samples *uint8
+	var size uint32
+	if !sdl.LoadWAVIO(sdl.IOFromConstMem(data), true, &sound.Spec, &samples, &size) {
+		return nil, errors.New(sdl.GetError())
+	}
+	defer sdl.Free(unsafe.Pointer(samples))
+	sound.Data = append([]byte(nil), unsafe.Slice(samples, size)...)
+	return sound, nil
+}
+
+// Duration returns the length of the sound in seconds
+func (s *Sound) Duration() float32 {
+	frame := int(s.Spec.Channels) * bytesPerSample(s.Spec.Format)
+	if frame == 0 || s.Spec.Freq == 0 {
+		return 0
+	}
+	return float32(len(s.Data)/frame) / float32(s.Spec.Freq)
+}
+
+// Helper function returning the size of one sample of a format in bytes
+func bytesPerSample(format sdl.AudioFormat) int {
+	return int(format&0xFF) / 8 // SDL_AUDIO_BITSIZE
+}
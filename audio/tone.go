@@ -0,0 +1,35 @@
+// tone.go
+package audio
+
+// Generated tones: short beeps and chimes for feedback sounds, so simple
+// sounds need no audio files.
+
+import (
+	"math"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Sample rate of generated sounds
+const toneRate = 44100
+
+// Tone generates notes played one after the other, each seconds long, at the
+// frequencies in Hz. Every note starts softly and decays, so it doesn't click.
+func Tone(seconds float32, frequencies ...float32) *Sound {
+	noteLength := int(seconds * toneRate)
+	samples := make([]float32, 0, noteLength*len(frequencies))
+	attack := float64(toneRate) * 0.005
+	for _, frequency := range frequencies {
+		for i := range noteLength {
+			t := float64(i) / toneRate
+			envelope := math.Min(float64(i)/attack, 1) * math.Exp(-5*t/float64(seconds))
+			samples = append(samples, float32(0.4*envelope*math.Sin(2*math.Pi*float64(frequency)*t)))
+		}
+	}
+	sound := &Sound{Spec: sdl.AudioSpec{Format: sdl.AudioF32, Channels: 1, Freq: toneRate}}
+	if len(samples) > 0 {
+		sound.Data = append([]byte(nil), unsafe.Slice((*byte)(unsafe.Pointer(&samples[0])), len(samples)*4)...)
+	}
+	return sound
+}
@@ -0,0 +1,142 @@
+// wav.go
+package audio
+
+// WAV parsing for streaming: finds the format and the sample data of a file
+// and reads the samples in chunks, so long files are never loaded whole.
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// WAV encodings (wFormatTag)
+const (
+	wavPCM        = 1
+	wavFloat      = 3
+	wavExtensible = 0xFFFE // The encoding follows in the sub-format
+)
+
+// Helper type reading the samples of a WAV file
+type wavReader struct {
+	r         io.ReadSeeker
+	spec      sdl.AudioSpec
+	frameSize int   // Bytes per sample frame (all channels)
+	dataStart int64 // Offset of the samples in the file
+	dataSize  int64 // -1 when the header doesn't know (read to the end)
+	position  int64 // Bytes read from the samples
+}
+
+// Helper function reading the header of a WAV file up to its samples
+func newWAVReader(r io.ReadSeeker) (*wavReader, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, err
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, errors.New("not a WAV file")
+	}
+	w := &wavReader{r: r}
+	haveFormat := false
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, fmt.Errorf("WAV samples not found: %w", err)
+		}
+		size := int64(binary.LittleEndian.Uint32(header[4:8]))
+		switch string(header[0:4]) {
+		case "fmt ":
+			chunk := make([]byte, size)
+			if _, err := io.ReadFull(r, chunk); err != nil {
+				return nil, err
+			}
+			if err := w.parseFormat(chunk); err != nil {
+				return nil, err
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, errors.New("WAV samples before the format")
+			}
+			start, err := r.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return nil, err
+			}
+			w.dataStart, w.dataSize = start, size
+			if size == 0 || size == 0xFFFFFFFF {
+				w.dataSize = -1 // Written while streaming
+			}
+			return w, nil
+		default:
+			if _, err := r.Seek(size, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+		}
+		if size%2 == 1 {
+			r.Seek(1, io.SeekCurrent) // Chunks are padded to even sizes
+		}
+	}
+}
+
+// Helper function reading the fmt chunk into the spec
+func (w *wavReader) parseFormat(chunk []byte) error {
+	if len(chunk) < 16 {
+		return errors.New("WAV format too short")
+	}
+	encoding := binary.LittleEndian.Uint16(chunk[0:2])
+	channels := binary.LittleEndian.Uint16(chunk[2:4])
+	rate := binary.LittleEndian.Uint32(chunk[4:8])
+	bits := binary.LittleEndian.Uint16(chunk[14:16])
+	if encoding == wavExtensible && len(chunk) >= 26 {
+		encoding = binary.LittleEndian.Uint16(chunk[24:26]) // First bytes of the sub-format GUID
+	}
+
+	var format sdl.AudioFormat
+	switch {
+	case encoding == wavPCM && bits == 8:
+		format = sdl.AudioU8
+	case encoding == wavPCM && bits == 16:
+		format = sdl.AudioS16Le
+	case encoding == wavPCM && bits == 32:
+		format = sdl.AudioS32Le
+	case encoding == wavFloat && bits == 32:
+		format = sdl.AudioF32Le
+	default:
+		return fmt.Errorf("WAV encoding %d with %d bits not supported", encoding, bits)
+	}
+	if channels == 0 || rate == 0 {
+		return errors.New("WAV without channels or sample rate")
+	}
+	w.spec = sdl.AudioSpec{Format: format, Channels: int32(channels), Freq: int32(rate)}
+	w.frameSize = int(channels) * int(bits) / 8
+	return nil
+}
+
+// Read reads samples, io.EOF after the last one
+func (w *wavReader) Read(p []byte) (int, error) {
+	if w.dataSize >= 0 {
+		left := w.dataSize - w.position
+		if left <= 0 {
+			return 0, io.EOF
+		}
+		p = p[:min(int64(len(p)), left)]
+	}
+	n, err := w.r.Read(p)
+	w.position += int64(n)
+	return n, err
+}
+
+// Rewind goes back to the first sample
+func (w *wavReader) Rewind() error {
+	_, err := w.r.Seek(w.dataStart, io.SeekStart)
+	w.position = 0
+	return err
+}
+
+// Helper function returning the bytes of one second of samples
+func (w *wavReader) bytesPerSecond() int {
+	return w.frameSize * int(w.spec.Freq)
+}
@@ -0,0 +1,556 @@
+// main.go
+package main
+
+// Demo application: a toolbar with buttons and a counter, a text input, a
+// square to move with the keyboard or mouse and an alert dialog.
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+
+	"arkenidar.com/purego-sdl3/app"
+	"arkenidar.com/purego-sdl3/ui"
+)
+
+// Function to render text at bottom with alignment and wrapping.
+// Effects (outline/shadow) keep the text readable over any background.
+func renderBottomText(renderer *sdl.Renderer, font *ttf.Font, text string, windowWidth, windowHeight, margin float32, align ui.TextAlign, effects ui.TextEffects) {
+	maxWidth := windowWidth - (margin * 2) // Available width for text
+	lines := ui.WrapTextLines(text, font, maxWidth)
+
+	if len(lines) == 0 {
+		return
+	}
+
+	// Calculate total height needed for all lines
+	lineHeight := ui.LineSkip(font)
+
+	totalHeight := lineHeight * float32(len(lines))
+	startY := windowHeight - totalHeight - margin
+
+	// Ensure text doesn't go above the window
+	if startY < margin {
+		startY = margin
+	}
+
+	// Render each line
+	for i, line := range lines {
+		y := startY + (float32(i) * lineHeight)
+		ui.DrawAlignedLine(renderer, font, line, margin, y, maxWidth, align, sdl.Color{R: 255, G: 255, B: 255, A: 255}, effects)
+	}
+}
+
+// Speed of the square while an arrow key is held (pixels per second)
+const squareSpeed = 400
+
+func main() {
+	config := app.DefaultConfig()
+	// Options from the command line, e.g. -width 1024 -fullscreen -ui-scale 1.5
+	config.ParseFlags()
+	config.Organization, config.AppName = "arkenidar", "go-sdl3-demo" // Keeps settings between runs
+	config.MinWidth, config.MinHeight = 400, 300                      // The toolbar and text input still fit
+	config.SingleInstance = true                                      // Starting it again raises this window
+	a := app.New(config)
+
+	// SECTION : Application state
+	x, y := float32(150), float32(150)
+	counter := 0
+	alertAlign := ui.AlignCenter // Styled alert lines support left, center and right
+	alertMessage := "[b]Button clicked![/b] This is a longer message that will demonstrate the [color=#a00]text wrapping[/color] functionality in alert dialogs."
+
+	// Dark outline and shadow keep the white instruction text readable
+	bottomTextEffects := ui.TextEffects{
+		OutlineColor:     sdl.Color{R: 0, G: 0, B: 0, A: 255},
+		OutlineThickness: 1,
+		ShadowColor:      sdl.Color{R: 0, G: 0, B: 0, A: 128},
+		ShadowOffsetX:    2,
+		ShadowOffsetY:    2,
+	}
+
+	// Widgets and effects, created once the window exists
+	var (
+		sdfBackend   *ui.SDFTextBackend
+		uiLayout     *ui.Layout
+		counterLabel *ui.Label
+		newButton    *ui.Button
+		textInput    *ui.TextInput
+		post         *ui.PostProcessor
+		battery      *ui.BatteryIndicator
+		inspector    *ui.Inspector
+	)
+
+	// Drag offset of the square from the mouse
+	dragOffsetX, dragOffsetY := float32(0), float32(0)
+
+	// The square lives in the world layer: wheel zooms, right/middle drag pans
+	camera := ui.NewCamera2D()
+
+	// Alert fades in and out
+	alertFade := ui.NewFade(0.15, false)
+
+	// Counter changes and square moves can be undone with Ctrl+Z and redone with Ctrl+Y
+	commands := app.NewCommandStack(100)
+	moving := false
+	moveFromX, moveFromY := float32(0), float32(0)
+
+	// Helper functions recording a square move (drag or held keys) as one command
+	beginMove := func() {
+		if !moving {
+			moving = true
+			moveFromX, moveFromY = x, y
+		}
+	}
+	endMove := func() {
+		if !moving {
+			return
+		}
+		moving = false
+		fromX, fromY, toX, toY := moveFromX, moveFromY, x, y
+		if fromX == toX && fromY == toY {
+			return
+		}
+		commands.Do(&app.FuncCommand{
+			Name:     "Move square",
+			DoFunc:   func() { x, y = toX, toY },
+			UndoFunc: func() { x, y = fromX, fromY },
+		})
+	}
+
+	// UI modes: from "normal" the square can be dragged or the view panned,
+	// the alert covers everything until it is dismissed
+	mode := app.NewStateMachine()
+	mode.Add("normal", "", nil, nil)
+	mode.Add("dragging", "normal", func() {
+		beginMove()
+		a.Cursors.Hold(sdl.SystemCursorMove)
+	}, func() {
+		endMove()
+		a.Cursors.Release()
+	})
+	mode.Add("panning", "normal", func() {
+		a.Cursors.Hold(sdl.SystemCursorMove)
+		a.SetRelativeMouse(true) // Pan past the window edges
+	}, func() {
+		a.SetRelativeMouse(false)
+		a.Cursors.Release()
+	})
+	mode.Add("alert", "", func() { a.Focus.SetFocus(nil) }, nil) // Keys go to the alert only
+	mode.Allow("normal", "dragging", "panning", "alert")
+	mode.Allow("dragging", "normal")
+	mode.Allow("panning", "normal")
+	mode.Allow("alert", "normal")
+	mode.Start("normal")
+
+	// Helper function keeping the square within window bounds
+	clampSquare := func() {
+		x = max(0, min(x, a.Width-100))
+		y = max(0, min(y, a.Height-100))
+	}
+
+	// Helper function positioning the right-aligned button and the top row left of it
+	layoutTopRow := func() {
+		newButton.Bounds.X = a.Width - newButton.Bounds.W - 10 // 10px margin from right edge
+		newButton.Bounds.Y = 10                                // Align with the top button row
+		uiLayout.Width = newButton.Bounds.X - 10 - uiLayout.X
+		uiLayout.Relayout()
+	}
+
+	a.OnInit = func() {
+		font, renderer := a.Font, a.Renderer
+
+		// Keep zoomed text crisp (falls back to bitmaps if SDF is unsupported)
+		sdfBackend = ui.NewSDFTextBackend()
+		ui.SetTextBackend(sdfBackend)
+
+		// Create UI layout with buttons and counter (positioned at top)
+		uiLayout = ui.NewLayout(10, 10, 10)
+		uiLayout.CacheRender = true // Toolbar rarely changes, reuse its texture
+
+		// Create buttons publishing their actions on the bus (auto-sized)
+		plusButton := ui.NewButton(0, 0, 0, 0, "+", font, renderer, nil)
+		plusButton.Action = "counter.increment"
+		minusButton := ui.NewButton(0, 0, 0, 0, "-", font, renderer, nil)
+		minusButton.Action = "counter.decrement"
+
+		// Create counter label
+		counter = a.Settings.Int("counter", 0) // Restored from the last run
+		counterLabel = ui.NewLabel(0, 0, "", font, renderer)
+		ui.BindLabel(counterLabel, &counter, "Counter: %d") // Follows the counter by itself
+		counterLabel.Selectable = true
+		counterLabel.SetStyle(ttf.StyleBold)
+		counterLabel.Truncate = ui.TruncateEnd // Shorten instead of running under the right button
+		counterLabel.OnDoubleClick = func() {
+			before := counter
+			commands.Do(&app.FuncCommand{
+				Name:     "Reset counter",
+				DoFunc:   func() { counter = 0 },
+				UndoFunc: func() { counter = before },
+			})
+		}
+
+		// Add widgets to main layout
+		uiLayout.AddWidget(plusButton)
+		uiLayout.AddWidget(minusButton)
+		uiLayout.AddWidget(counterLabel)
+
+		// Create a right-aligned button (demonstration of extensibility - auto-sized)
+		newButton = ui.NewButton(0, 0, 0, 0, "&Click Me", font, renderer, nil)
+		newButton.Action = "alert.show"
+		layoutTopRow()
+
+		// Create a text input below the top row (supports IME composition)
+		textInput = ui.NewTextInput(10, 60, 300, font, renderer, a.Window)
+
+		// Battery level next to the text input, on devices with a battery
+		battery = ui.NewBatteryIndicator(320, 66, 40, 20)
+		a.OnPowerChanged(30, func(info app.PowerInfo) {
+			battery.SetState(info.Percent, info.Charging())
+			battery.Opacity = 0
+			if info.HasBattery() {
+				battery.Opacity = 1
+			}
+		})
+
+		// Blurs the scene behind the alert, F2 toggles a grayscale "disabled" look
+		post = ui.NewPostProcessor()
+
+		// F12 shows the widget tree for debugging layouts
+		inspector = ui.NewInspector(font, uiLayout, newButton, textInput)
+
+		// Tab moves keyboard focus through the buttons and the text input
+		a.Focus.Roots = []ui.Widget{uiLayout, newButton, textInput}
+		a.Focus.StickNavigation = false  // The stick moves the square
+		a.Cursors.Roots = a.Focus.Roots  // Hand over buttons, I-beam over text
+		a.Gestures.Roots = a.Focus.Roots // Long-press selects text on touch screens
+		a.Gestures.OnGesture = func(gesture ui.Gesture) {
+			// Pinch zooms the world layer, two fingers pan it
+			if !mode.In("alert") {
+				camera.ApplyGesture(gesture)
+			}
+		}
+
+		// App state reacts to the actions the widgets publish
+		ui.Bus.Subscribe("counter.*", func(topic string, data any) {
+			before, after := counter, counter+1
+			if topic == "counter.decrement" {
+				after = counter - 1
+			}
+			commands.Do(&app.FuncCommand{
+				Name:     "Change counter",
+				DoFunc:   func() { counter = after },
+				UndoFunc: func() { counter = before },
+			})
+		})
+		ui.Bus.Subscribe("alert.show", func(topic string, data any) {
+			mode.Go("alert")
+		})
+
+		// Advance animations, animated widgets keep frames coming
+		a.OnTick(uiLayout.Tick)
+		// The alert and the blur behind it fade together, the tick keeps
+		// frames coming until both settled
+		a.OnTick(func(dt float32) bool {
+			alertFade.SetVisible(mode.In("alert"))
+			fading := alertFade.Tick(dt)
+			post.Blur = 0
+			if alertFade.Value > 0 {
+				post.Blur = 1 + 5*alertFade.Value
+			}
+			return fading
+		})
+
+		// Keyboard shortcuts (run before the widgets get the keys)
+		a.Shortcuts.Bind("Escape", "Close", func() {
+			if mode.In("alert") {
+				mode.Go("normal") // Dismiss alert first
+			} else {
+				a.Quit() // Exit application
+			}
+		})
+		a.Shortcuts.Bind("Space", "Dismiss alert", func() {
+			if mode.In("alert") {
+				mode.Go("normal")
+			}
+		})
+		a.Shortcuts.Bind("F2", "Toggle grayscale", func() {
+			post.Grayscale = !post.Grayscale
+			ui.PlaySound(ui.SoundToggle)
+		})
+		a.Shortcuts.Bind("Ctrl+M", "Mute sounds", a.ToggleMuted)
+		a.Shortcuts.Bind("Ctrl+C", "Copy", func() {
+			if mode.In("alert") {
+				ui.SetClipboardText(ui.StripMarkup(alertMessage)) // Copy alert text out
+			} else {
+				counterLabel.Copy()
+			}
+		})
+		a.Shortcuts.Bind("Ctrl+Shift+C", "Copy counter value", func() {
+			ui.SetClipboardText(strconv.Itoa(counter))
+		})
+		a.Shortcuts.Bind("Ctrl+Shift+N", "Notify in 5 seconds", func() {
+			// Shown by the system, so it also works with the window minimized
+			a.After(5, func() {
+				a.Notify("Reminder", "Counter: "+strconv.Itoa(counter), "")
+			})
+		})
+
+		// Brings the window to the front from any application (Windows only)
+		if err := a.RegisterGlobalHotkey("Ctrl+Alt+Shift+D", func() {
+			sdl.RestoreWindow(a.Window)
+			sdl.RaiseWindow(a.Window)
+		}); err != nil {
+			slog.Info("no global hotkey", "error", err)
+		}
+	}
+
+	// Files dropped on the window: show their paths in the text input
+	a.OnFilesDropped = func(paths []string, x, y float32) {
+		textInput.SetText(strings.Join(paths, ", "))
+	}
+
+	// Docked or undocked: the window is back on screen and text at the new scale
+	a.OnDisplaysChanged = func() {
+		layoutTopRow()
+		clampSquare()
+	}
+
+	// The demo was started again: show that copy's arguments in the text input
+	a.OnSecondInstance = func(args []string, workingDir string) {
+		textInput.SetText(strings.Join(args, " "))
+	}
+
+	a.OnQuit = func() {
+		a.Settings.SetInt("counter", counter)
+		post.Destroy()
+		textInput.Destroy()
+		newButton.Destroy()
+		uiLayout.Destroy()
+		sdfBackend.Destroy()
+	}
+
+	a.OnEvent = func(event sdl.Event) {
+		// Get mouse position for widgets
+		mx, my := ui.EventPosition(event)
+
+		if inspector.Update(event, mx, my) {
+			return
+		}
+
+		switch event.Type() {
+		case sdl.EventWindowResized:
+			// Reposition right-aligned button and keep the square inside the window
+			layoutTopRow()
+			clampSquare()
+		case sdl.EventWindowDisplayScaleChanged:
+			// Text was re-rendered at the new scale, sizes may have changed
+			layoutTopRow()
+		case sdl.EventKeyDown:
+			// Keys the focused widget didn't use (the App gave them to it first): undo/redo
+			if !mode.In("alert") {
+				commands.HandleEvent(event)
+			}
+		case sdl.EventMouseButtonDown:
+			// Check if alert is showing and handle click-to-close
+			if mode.In("alert") {
+				mode.Go("normal") // Dismiss alert on any click
+			} else if button := sdl.MouseButtonFlags(event.Button().Button); button == sdl.ButtonRight || button == sdl.ButtonMiddle {
+				mode.Go("panning")
+			} else if !textInput.Update(event, mx, my) {
+				// Check if UI layout handled the event first
+				if !uiLayout.Update(event, mx, my) {
+					// Check if right-aligned button handled the event
+					if !newButton.Update(event, mx, my) {
+						// Check if mouse is inside the square for dragging (in world coordinates)
+						wx, wy := camera.ScreenToWorld(mx, my)
+						if wx >= x && wx <= x+100 && wy >= y && wy <= y+100 {
+							mode.Go("dragging")
+							dragOffsetX = wx - x
+							dragOffsetY = wy - y
+						}
+					}
+				}
+			}
+		case sdl.EventMouseButtonUp:
+			textInput.Update(event, mx, my) // Finish drag selection
+			uiLayout.Update(event, mx, my)
+			newButton.Update(event, mx, my) // Handle button release for right-aligned button
+			if mode.In("dragging") || mode.In("panning") {
+				mode.Go("normal") // Ends the move
+			}
+		case sdl.EventWindowFocusLost:
+			// The release won't arrive once another window took the mouse
+			if mode.In("dragging") || mode.In("panning") {
+				mode.Go("normal")
+			}
+		case sdl.EventWindowMouseLeave:
+			// Nothing stays highlighted while the mouse is outside the window
+			uiLayout.Update(event, mx, my)
+			newButton.Update(event, mx, my)
+		case sdl.EventMouseWheel:
+			// Scrollable widgets under the mouse first, else zoom the world layer towards the mouse
+			if !mode.In("alert") && !uiLayout.Update(event, mx, my) {
+				_, steps := ui.WheelDelta(event)
+				camera.ZoomAt(float32(math.Pow(1.1, float64(steps))), mx, my)
+			}
+		case sdl.EventMouseMotion:
+			// Extend text selections while dragging over text widgets, move hover highlights
+			hoverChanged := newButton.Update(event, mx, my)
+			if textInput.Update(event, mx, my) || uiLayout.Update(event, mx, my) || hoverChanged {
+				a.Redraw()
+			}
+			if mode.In("panning") {
+				a.Redraw()
+				camera.Pan(event.Motion().Xrel, event.Motion().Yrel)
+			}
+			if mode.In("dragging") {
+				a.Redraw()
+				wx, wy := camera.ScreenToWorld(mx, my)
+				x = wx - dragOffsetX
+				y = wy - dragOffsetY
+				clampSquare()
+			}
+		}
+	}
+
+	a.OnTick(func(dt float32) bool {
+		// Move the square while arrow keys are held, at the same speed at any
+		// frame rate. Returning true keeps the loop running while a key is held.
+		if !mode.In("alert") && !textInput.Focused {
+			dx := app.KeyAxis(sdl.ScancodeLeft, sdl.ScancodeRight)
+			dy := app.KeyAxis(sdl.ScancodeUp, sdl.ScancodeDown)
+			// Gamepad left stick (moves slower when pushed less, the d-pad navigates the UI)
+			dx += a.Gamepads.Axis(sdl.GamepadAxisLeftX)
+			dy += a.Gamepads.Axis(sdl.GamepadAxisLeftY)
+			// Diagonals are no faster than straight moves
+			if length := float32(math.Hypot(float64(dx), float64(dy))); length > 1 {
+				dx, dy = dx/length, dy/length
+			}
+			if dx != 0 || dy != 0 {
+				beginMove()
+				x += dx * squareSpeed * dt
+				y += dy * squareSpeed * dt
+				clampSquare()
+				return true
+			} else if !mode.In("dragging") {
+				endMove()
+			}
+		}
+		return false
+	})
+
+	a.OnRender = func(renderer *sdl.Renderer) {
+		font := a.Font
+		windowWidth, windowHeight := a.Width, a.Height
+
+		post.Begin(renderer, windowWidth, windowHeight)
+		if a.Theme() == app.ThemeLight {
+			sdl.SetRenderDrawColor(renderer, 100, 150, 200, sdl.AlphaOpaque)
+		} else {
+			sdl.SetRenderDrawColor(renderer, 35, 50, 75, sdl.AlphaOpaque) // Follows the system dark mode
+		}
+		sdl.RenderClear(renderer)
+
+		// Draw rectangle in the world layer
+		camera.Begin(renderer)
+		rect := camera.WorldRectToView(sdl.FRect{X: x, Y: y, W: 100, H: 100})
+		ui.SetDrawColor(renderer, 0, 0, 200, sdl.AlphaOpaque)
+		sdl.RenderFillRect(renderer, &rect)
+		// Zoomed text stays crisp, rendered by the SDF backend at the camera's zoom
+		camera.DrawText(renderer, font, "Drag me", x+10, y+10, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		camera.End(renderer)
+
+		// Render UI elements
+		uiLayout.Render(renderer)
+		newButton.Render(renderer) // Render the right-aligned button separately
+		textInput.Render(renderer)
+		battery.Render(renderer)
+
+		// Render instruction text at bottom with centering and wrapping
+		renderBottomText(renderer, font, "• move the blue square with arrow keys or mouse drag\n • click its buttons to change counter", windowWidth, windowHeight, 10, ui.AlignCenter, bottomTextEffects)
+
+		// Composite the scene with its effects, the alert stays sharp
+		post.End(renderer)
+
+		// Render alert while it is (fading) visible
+		if alertFade.Value > 0 {
+			restoreOpacity := ui.PushOpacity(alertFade.Value)
+
+			// Calculate available width for alert text (with padding)
+			maxAlertWidth := windowWidth * 0.8 // Use 80% of window width max
+			if maxAlertWidth < 200 {
+				maxAlertWidth = 200 // Minimum width
+			}
+
+			// Wrap alert text (may contain markup) and dismiss text
+			alertLines := ui.WrapSpans(ui.ParseMarkup(alertMessage), font, maxAlertWidth-40) // Subtract padding
+			dismissLines := ui.WrapText("Press ESC/SPACE or click to close, Ctrl+C to copy", font, maxAlertWidth-40)
+
+			// Calculate dimensions for wrapped text
+			lineHeight := ui.LineSkip(font)
+
+			// Find the widest line to determine alert box width
+			var maxLineWidth float32
+			for _, line := range alertLines {
+				lineWidth, _ := ui.MeasureSpans(font, line)
+				if lineWidth > maxLineWidth {
+					maxLineWidth = lineWidth
+				}
+			}
+			for _, line := range dismissLines {
+				lineWidth := ui.MeasureText(font, line)
+				if lineWidth > maxLineWidth {
+					maxLineWidth = lineWidth
+				}
+			}
+
+			// Calculate alert box dimensions
+			alertBoxW := maxLineWidth + 40 // 20px padding on each side
+			totalTextHeight := lineHeight * float32(len(alertLines)+len(dismissLines))
+			alertBoxH := totalTextHeight + 60           // Text heights + spacing + padding
+			alertBoxX := (windowWidth - alertBoxW) / 2  // Center horizontally
+			alertBoxY := (windowHeight - alertBoxH) / 2 // Center vertically
+
+			// Semi-transparent overlay
+			ui.SetDrawColor(renderer, 0, 0, 0, 128)
+			overlay := sdl.FRect{X: 0, Y: 0, W: windowWidth, H: windowHeight}
+			sdl.RenderFillRect(renderer, &overlay)
+
+			// Auto-sized alert box
+			alertBox := sdl.FRect{X: alertBoxX, Y: alertBoxY, W: alertBoxW, H: alertBoxH}
+			ui.FillRoundedRect(renderer, alertBox, 10, sdl.Color{R: 200, G: 200, B: 200, A: 255})
+
+			// Alert box border
+			ui.DrawRoundedRect(renderer, alertBox, 10, 1, sdl.Color{R: 100, G: 100, B: 100, A: 255})
+
+			// Render alert text lines (aligned within the padded alert box)
+			currentY := alertBox.Y + 20
+			for _, line := range alertLines {
+				textW, _ := ui.MeasureSpans(font, line)
+				textX := ui.AlignedX(alertBox.X+20, alertBox.W-40, textW, alertAlign)
+				ui.DrawSpans(renderer, font, line, textX, currentY, sdl.Color{R: 0, G: 0, B: 0, A: 255})
+				currentY += lineHeight
+			}
+
+			// Add spacing between alert text and dismiss text
+			currentY += 20
+
+			// Render dismiss instruction lines (centered)
+			for _, line := range dismissLines {
+				// Center the line horizontally within the alert box
+				textX := alertBox.X + (alertBox.W-ui.MeasureText(font, line))/2
+				ui.DrawText(renderer, font, line, textX, currentY, sdl.Color{R: 0, G: 0, B: 0, A: 255})
+				currentY += lineHeight
+			}
+			restoreOpacity()
+		}
+
+		inspector.Render(renderer)
+	}
+
+	a.Run()
+}
@@ -2,6 +2,7 @@ module arkenidar.com/purego-sdl3
 
 go 1.24.5
 
-require github.com/jupiterrider/purego-sdl3 v0.0.0-20250719115106-53f86090ce3d
-
-require github.com/ebitengine/purego v0.8.3 // indirect
+require (
+	github.com/ebitengine/purego v0.8.3
+	github.com/jupiterrider/purego-sdl3 v0.0.0-20250719115106-53f86090ce3d
+)
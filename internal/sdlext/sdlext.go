@@ -0,0 +1,403 @@
+// sdlext.go
+
+// Package sdlext binds SDL functions that purego-sdl3 doesn't expose yet.
+package sdlext
+
+// The functions are registered against the same SDL library the sdl package loads.
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+var (
+	sdlSetClipboardText func(text string) bool
+	sdlHasClipboardText func() bool
+	sdlGetPrefPath      func(org, app string) *byte
+	sdlFree             func(mem *byte)
+
+	sdlGetDisplayBounds        func(displayID sdl.DisplayID, rect *sdl.Rect) bool
+	sdlGetDisplayUsableBounds  func(displayID sdl.DisplayID, rect *sdl.Rect) bool
+	sdlGetWindowFlags          func(window *sdl.Window) sdl.WindowFlags
+	sdlMaximizeWindow          func(window *sdl.Window) bool
+	sdlMinimizeWindow          func(window *sdl.Window) bool
+	sdlGetSystemTheme          func() sdl.SystemTheme
+	sdlSetWindowMinimumSize    func(window *sdl.Window, w, h int32) bool
+	sdlSetWindowMaximumSize    func(window *sdl.Window, w, h int32) bool
+	sdlSetWindowAspectRatio    func(window *sdl.Window, minAspect, maxAspect float32) bool
+	sdlEnableScreenSaver       func() bool
+	sdlDisableScreenSaver      func() bool
+	sdlScreenSaverEnabled      func() bool
+	sdlGetWindowBordersSize    func(window *sdl.Window, top, left, bottom, right *int32) bool
+	sdlMalloc                  func(size uint64) unsafe.Pointer
+	sdlHasClipboardData        func(mimeType string) bool
+	sdlGetClipboardData        func(mimeType string, size *uint64) unsafe.Pointer
+	sdlSetClipboardData        func(callback, cleanup uintptr, userdata unsafe.Pointer, mimeTypes **byte, numMimeTypes uint64) bool
+	sdlOpenAudioDevice         func(devid sdl.AudioDeviceID, spec *sdl.AudioSpec) sdl.AudioDeviceID
+	sdlCloseAudioDevice        func(devid sdl.AudioDeviceID)
+	sdlGetAudioDeviceFormat    func(devid sdl.AudioDeviceID, spec *sdl.AudioSpec, sampleFrames *int32) bool
+	sdlGetAudioDeviceGain      func(devid sdl.AudioDeviceID) float32
+	sdlSetAudioDeviceGain      func(devid sdl.AudioDeviceID, gain float32) bool
+	sdlPauseAudioDevice        func(devid sdl.AudioDeviceID) bool
+	sdlResumeAudioDevice       func(devid sdl.AudioDeviceID) bool
+	sdlCreateAudioStream       func(srcSpec, dstSpec *sdl.AudioSpec) *sdl.AudioStream
+	sdlBindAudioStream         func(devid sdl.AudioDeviceID, stream *sdl.AudioStream) bool
+	sdlUnbindAudioStream       func(stream *sdl.AudioStream)
+	sdlGetAudioStreamAvailable func(stream *sdl.AudioStream) int32
+	sdlSetAudioStreamGain      func(stream *sdl.AudioStream, gain float32) bool
+)
+
+// Added in SDL 3.4, nil with older libraries (see GPURenderStatesSupported)
+var (
+	sdlCreateGPURenderState              func(renderer *sdl.Renderer, createInfo *GPURenderStateCreateInfo) *GPURenderState
+	sdlSetGPURenderStateFragmentUniforms func(state *GPURenderState, slotIndex uint32, data unsafe.Pointer, length uint32) bool
+	sdlSetRenderGPUState                 func(renderer *sdl.Renderer, state *GPURenderState) bool
+	sdlDestroyGPURenderState             func(state *GPURenderState)
+)
+
+func init() {
+	lib, err := loadSDLLibrary()
+	if err != nil {
+		panic(err)
+	}
+
+	purego.RegisterLibFunc(&sdlSetClipboardText, lib, "SDL_SetClipboardText")
+	purego.RegisterLibFunc(&sdlHasClipboardText, lib, "SDL_HasClipboardText")
+	purego.RegisterLibFunc(&sdlGetPrefPath, lib, "SDL_GetPrefPath")
+	purego.RegisterLibFunc(&sdlFree, lib, "SDL_free")
+	purego.RegisterLibFunc(&sdlGetDisplayBounds, lib, "SDL_GetDisplayBounds")
+	purego.RegisterLibFunc(&sdlGetDisplayUsableBounds, lib, "SDL_GetDisplayUsableBounds")
+	purego.RegisterLibFunc(&sdlGetWindowFlags, lib, "SDL_GetWindowFlags")
+	purego.RegisterLibFunc(&sdlMaximizeWindow, lib, "SDL_MaximizeWindow")
+	purego.RegisterLibFunc(&sdlMinimizeWindow, lib, "SDL_MinimizeWindow")
+	purego.RegisterLibFunc(&sdlGetSystemTheme, lib, "SDL_GetSystemTheme")
+	purego.RegisterLibFunc(&sdlSetWindowMinimumSize, lib, "SDL_SetWindowMinimumSize")
+	purego.RegisterLibFunc(&sdlSetWindowMaximumSize, lib, "SDL_SetWindowMaximumSize")
+	purego.RegisterLibFunc(&sdlSetWindowAspectRatio, lib, "SDL_SetWindowAspectRatio")
+	purego.RegisterLibFunc(&sdlEnableScreenSaver, lib, "SDL_EnableScreenSaver")
+	purego.RegisterLibFunc(&sdlDisableScreenSaver, lib, "SDL_DisableScreenSaver")
+	purego.RegisterLibFunc(&sdlScreenSaverEnabled, lib, "SDL_ScreenSaverEnabled")
+	purego.RegisterLibFunc(&sdlGetWindowBordersSize, lib, "SDL_GetWindowBordersSize")
+	purego.RegisterLibFunc(&sdlMalloc, lib, "SDL_malloc")
+	purego.RegisterLibFunc(&sdlHasClipboardData, lib, "SDL_HasClipboardData")
+	purego.RegisterLibFunc(&sdlGetClipboardData, lib, "SDL_GetClipboardData")
+	purego.RegisterLibFunc(&sdlSetClipboardData, lib, "SDL_SetClipboardData")
+	purego.RegisterLibFunc(&sdlOpenAudioDevice, lib, "SDL_OpenAudioDevice")
+	purego.RegisterLibFunc(&sdlCloseAudioDevice, lib, "SDL_CloseAudioDevice")
+	purego.RegisterLibFunc(&sdlGetAudioDeviceFormat, lib, "SDL_GetAudioDeviceFormat")
+	purego.RegisterLibFunc(&sdlGetAudioDeviceGain, lib, "SDL_GetAudioDeviceGain")
+	purego.RegisterLibFunc(&sdlSetAudioDeviceGain, lib, "SDL_SetAudioDeviceGain")
+	purego.RegisterLibFunc(&sdlPauseAudioDevice, lib, "SDL_PauseAudioDevice")
+	purego.RegisterLibFunc(&sdlResumeAudioDevice, lib, "SDL_ResumeAudioDevice")
+	purego.RegisterLibFunc(&sdlCreateAudioStream, lib, "SDL_CreateAudioStream")
+	purego.RegisterLibFunc(&sdlBindAudioStream, lib, "SDL_BindAudioStream")
+	purego.RegisterLibFunc(&sdlUnbindAudioStream, lib, "SDL_UnbindAudioStream")
+	purego.RegisterLibFunc(&sdlGetAudioStreamAvailable, lib, "SDL_GetAudioStreamAvailable")
+	purego.RegisterLibFunc(&sdlSetAudioStreamGain, lib, "SDL_SetAudioStreamGain")
+
+	if fn := lookupFunc(lib, "SDL_CreateGPURenderState"); fn != 0 {
+		purego.RegisterFunc(&sdlCreateGPURenderState, fn)
+		purego.RegisterFunc(&sdlSetGPURenderStateFragmentUniforms, lookupFunc(lib, "SDL_SetGPURenderStateFragmentUniforms"))
+		purego.RegisterFunc(&sdlSetRenderGPUState, lookupFunc(lib, "SDL_SetRenderGPUState"))
+		purego.RegisterFunc(&sdlDestroyGPURenderState, lookupFunc(lib, "SDL_DestroyGPURenderState"))
+	}
+}
+
+// SetClipboardText puts text on the system clipboard
+func SetClipboardText(text string) bool {
+	return sdlSetClipboardText(text)
+}
+
+// HasClipboardText checks whether the clipboard holds non-empty text
+func HasClipboardText() bool {
+	return sdlHasClipboardText()
+}
+
+// GetPrefPath returns the per-user directory for writing application files
+// (ending in a path separator), creating it if needed. Empty on failure.
+func GetPrefPath(org, app string) string {
+	path := sdlGetPrefPath(org, app)
+	if path == nil {
+		return ""
+	}
+	defer sdlFree(path)
+	return goString(path)
+}
+
+// GetDisplayBounds gets the desktop area of a display, in screen coordinates
+func GetDisplayBounds(displayID sdl.DisplayID, rect *sdl.Rect) bool {
+	return sdlGetDisplayBounds(displayID, rect)
+}
+
+// GetDisplayUsableBounds gets the desktop area of a display not used by
+// taskbars and docks, in screen coordinates
+func GetDisplayUsableBounds(displayID sdl.DisplayID, rect *sdl.Rect) bool {
+	return sdlGetDisplayUsableBounds(displayID, rect)
+}
+
+// GetWindowFlags returns the current state of a window (maximized, fullscreen...)
+func GetWindowFlags(window *sdl.Window) sdl.WindowFlags {
+	return sdlGetWindowFlags(window)
+}
+
+// MaximizeWindow makes a resizable window as large as possible
+func MaximizeWindow(window *sdl.Window) bool {
+	return sdlMaximizeWindow(window)
+}
+
+// MinimizeWindow hides the window to the task bar or dock
+func MinimizeWindow(window *sdl.Window) bool {
+	return sdlMinimizeWindow(window)
+}
+
+// GetSystemTheme returns whether the system uses a light or dark theme
+func GetSystemTheme() sdl.SystemTheme {
+	return sdlGetSystemTheme()
+}
+
+// SetWindowMinimumSize keeps the user from making the window smaller, 0 for no limit
+func SetWindowMinimumSize(window *sdl.Window, w, h int32) bool {
+	return sdlSetWindowMinimumSize(window, w, h)
+}
+
+// SetWindowMaximumSize keeps the user from making the window larger, 0 for no limit
+func SetWindowMaximumSize(window *sdl.Window, w, h int32) bool {
+	return sdlSetWindowMaximumSize(window, w, h)
+}
+
+// SetWindowAspectRatio limits width/height while resizing, 0 for no limit
+func SetWindowAspectRatio(window *sdl.Window, minAspect, maxAspect float32) bool {
+	return sdlSetWindowAspectRatio(window, minAspect, maxAspect)
+}
+
+// EnableScreenSaver lets the display blank or sleep when the user is idle
+func EnableScreenSaver() bool {
+	return sdlEnableScreenSaver()
+}
+
+// DisableScreenSaver keeps the display on (SDL's default while video is initialized)
+func DisableScreenSaver() bool {
+	return sdlDisableScreenSaver()
+}
+
+// ScreenSaverEnabled reports whether the display may blank or sleep
+func ScreenSaverEnabled() bool {
+	return sdlScreenSaverEnabled()
+}
+
+// GetWindowBordersSize gets the size of the window decorations (title bar
+// and frame) around the client area, false if unknown
+func GetWindowBordersSize(window *sdl.Window, top, left, bottom, right *int32) bool {
+	return sdlGetWindowBordersSize(window, top, left, bottom, right)
+}
+
+// HasClipboardData checks whether the clipboard offers data of a MIME type
+func HasClipboardData(mimeType string) bool {
+	return sdlHasClipboardData(mimeType)
+}
+
+// GetClipboardData returns the clipboard data of a MIME type, nil if there is none
+func GetClipboardData(mimeType string) []byte {
+	var size uint64
+	data := sdlGetClipboardData(mimeType, &size)
+	if data == nil {
+		return nil
+	}
+	defer sdlFree((*byte)(data))
+	return append([]byte(nil), unsafe.Slice((*byte)(data), size)...)
+}
+
+// Data of one MIME type put on the clipboard with SetClipboardData, copied to SDL memory
+type clipboardEntry struct {
+	data unsafe.Pointer
+	size uint64
+}
+
+// Data put on the clipboard with one SetClipboardData call, by MIME type
+type clipboardOffer map[string]clipboardEntry
+
+var (
+	clipboardOnce     sync.Once
+	clipboardCallback uintptr // Hands an offer's data to SDL
+	clipboardCleanup  uintptr // Frees an offer replaced on the clipboard
+	clipboardMutex    sync.Mutex
+	clipboardOffers   = make(map[uintptr]clipboardOffer) // By the SDL memory passed as userdata
+)
+
+// SetClipboardData puts data on the clipboard under several MIME types (e.g.
+// the same image as PNG and BMP), other applications take the one they read
+func SetClipboardData(data map[string][]byte) bool {
+	// Callbacks are never freed, two serve every offer
+	clipboardOnce.Do(func() {
+		clipboardCallback = purego.NewCallback(func(userdata unsafe.Pointer, mimeType *byte, size *uint64) uintptr {
+			clipboardMutex.Lock()
+			defer clipboardMutex.Unlock()
+			entry, ok := clipboardOffers[uintptr(userdata)][goString(mimeType)]
+			if !ok {
+				*size = 0
+				return 0
+			}
+			*size = entry.size
+			return uintptr(entry.data)
+		})
+		clipboardCleanup = purego.NewCallback(func(userdata unsafe.Pointer) uintptr {
+			clipboardMutex.Lock()
+			defer clipboardMutex.Unlock()
+			for _, entry := range clipboardOffers[uintptr(userdata)] {
+				sdlFree((*byte)(entry.data))
+			}
+			delete(clipboardOffers, uintptr(userdata))
+			sdlFree((*byte)(userdata))
+			return 0
+		})
+	})
+
+	// SDL may ask for the data after Go memory moved on, keep copies in SDL memory
+	offer := make(clipboardOffer, len(data))
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+	mimeTypes := make([]*byte, 0, len(data))
+	for mimeType, bytes := range data {
+		copied := sdlMalloc(uint64(max(len(bytes), 1)))
+		copy(unsafe.Slice((*byte)(copied), len(bytes)), bytes)
+		offer[mimeType] = clipboardEntry{copied, uint64(len(bytes))}
+		name := append([]byte(mimeType), 0) // SDL copies the names
+		pinner.Pin(&name[0])
+		mimeTypes = append(mimeTypes, &name[0])
+	}
+	if len(mimeTypes) == 0 {
+		return false
+	}
+	token := sdlMalloc(1) // Identifies the offer in the callbacks
+	clipboardMutex.Lock()
+	clipboardOffers[uintptr(token)] = offer
+	clipboardMutex.Unlock()
+	return sdlSetClipboardData(clipboardCallback, clipboardCleanup, token, &mimeTypes[0], uint64(len(mimeTypes)))
+}
+
+// OpenAudioDevice opens a logical device on a physical one (e.g.
+// sdl.AudioDeviceDefaultPlayback), spec nil for its preferred format. Returns 0 on failure.
+func OpenAudioDevice(devid sdl.AudioDeviceID, spec *sdl.AudioSpec) sdl.AudioDeviceID {
+	return sdlOpenAudioDevice(devid, spec)
+}
+
+// CloseAudioDevice closes a device, its streams are unbound
+func CloseAudioDevice(devid sdl.AudioDeviceID) {
+	sdlCloseAudioDevice(devid)
+}
+
+// GetAudioDeviceFormat gets the format a device mixes in
+func GetAudioDeviceFormat(devid sdl.AudioDeviceID, spec *sdl.AudioSpec, sampleFrames *int32) bool {
+	return sdlGetAudioDeviceFormat(devid, spec, sampleFrames)
+}
+
+// GetAudioDeviceGain returns the volume of a logical device, 1 is unchanged
+func GetAudioDeviceGain(devid sdl.AudioDeviceID) float32 {
+	return sdlGetAudioDeviceGain(devid)
+}
+
+// SetAudioDeviceGain scales everything a logical device plays, 0 is silent
+func SetAudioDeviceGain(devid sdl.AudioDeviceID, gain float32) bool {
+	return sdlSetAudioDeviceGain(devid, gain)
+}
+
+// PauseAudioDevice stops a logical device pulling from its streams
+func PauseAudioDevice(devid sdl.AudioDeviceID) bool {
+	return sdlPauseAudioDevice(devid)
+}
+
+// ResumeAudioDevice continues a paused logical device
+func ResumeAudioDevice(devid sdl.AudioDeviceID) bool {
+	return sdlResumeAudioDevice(devid)
+}
+
+// CreateAudioStream creates a stream converting from srcSpec to dstSpec
+func CreateAudioStream(srcSpec, dstSpec *sdl.AudioSpec) *sdl.AudioStream {
+	return sdlCreateAudioStream(srcSpec, dstSpec)
+}
+
+// BindAudioStream plays a stream on a logical device
+func BindAudioStream(devid sdl.AudioDeviceID, stream *sdl.AudioStream) bool {
+	return sdlBindAudioStream(devid, stream)
+}
+
+// UnbindAudioStream stops playing a stream, its data stays queued
+func UnbindAudioStream(stream *sdl.AudioStream) {
+	sdlUnbindAudioStream(stream)
+}
+
+// GetAudioStreamAvailable returns the converted bytes waiting to be played, -1 on failure
+func GetAudioStreamAvailable(stream *sdl.AudioStream) int32 {
+	return sdlGetAudioStreamAvailable(stream)
+}
+
+// SetAudioStreamGain scales the volume of a stream, 1 is unchanged
+func SetAudioStreamGain(stream *sdl.AudioStream, gain float32) bool {
+	return sdlSetAudioStreamGain(stream, gain)
+}
+
+// Helper function copying a NUL-terminated C string
+func goString(p *byte) string {
+	n := 0
+	for *(*byte)(unsafe.Add(unsafe.Pointer(p), n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice(p, n))
+}
+
+// GPURenderState is a custom fragment shader and its resources used by the
+// 2D renderer on the "gpu" render driver
+type GPURenderState struct{}
+
+// GPURenderStateCreateInfo describes a GPURenderState (SDL_GPURenderStateCreateInfo)
+type GPURenderStateCreateInfo struct {
+	FragmentShader     *sdl.GPUShader
+	NumSamplerBindings int32
+	SamplerBindings    unsafe.Pointer // Extra textures after the one being drawn
+	NumStorageTextures int32
+	StorageTextures    unsafe.Pointer
+	NumStorageBuffers  int32
+	StorageBuffers     unsafe.Pointer
+	Props              sdl.PropertiesID
+}
+
+// GPURenderStatesSupported reports whether the SDL library can run custom
+// shaders in the 2D renderer (SDL 3.4 or newer)
+func GPURenderStatesSupported() bool {
+	return sdlCreateGPURenderState != nil
+}
+
+// CreateGPURenderState creates a render state for a renderer on the "gpu" driver, nil on failure
+func CreateGPURenderState(renderer *sdl.Renderer, createInfo *GPURenderStateCreateInfo) *GPURenderState {
+	if sdlCreateGPURenderState == nil {
+		return nil
+	}
+	return sdlCreateGPURenderState(renderer, createInfo)
+}
+
+// SetGPURenderStateFragmentUniforms sets the data of a uniform buffer slot of the fragment shader
+func SetGPURenderStateFragmentUniforms(state *GPURenderState, slotIndex uint32, data []byte) bool {
+	if sdlSetGPURenderStateFragmentUniforms == nil || len(data) == 0 {
+		return false
+	}
+	return sdlSetGPURenderStateFragmentUniforms(state, slotIndex, unsafe.Pointer(&data[0]), uint32(len(data)))
+}
+
+// SetRenderGPUState makes the following draw calls use state, nil goes back to the built-in shaders
+func SetRenderGPUState(renderer *sdl.Renderer, state *GPURenderState) bool {
+	if sdlSetRenderGPUState == nil {
+		return false
+	}
+	return sdlSetRenderGPUState(renderer, state)
+}
+
+// DestroyGPURenderState frees a render state
+func DestroyGPURenderState(state *GPURenderState) {
+	if sdlDestroyGPURenderState != nil {
+		sdlDestroyGPURenderState(state)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package sdlext
+
+import (
+	"runtime"
+
+	"github.com/ebitengine/purego"
+)
+
+// Open the SDL library (already loaded by the sdl package, so this just returns its handle)
+func loadSDLLibrary() (uintptr, error) {
+	filename := "libSDL3.so.0"
+	if runtime.GOOS == "darwin" {
+		filename = "libSDL3.dylib"
+	}
+	return purego.Dlopen(filename, purego.RTLD_LAZY)
+}
+
+// Helper function returning the address of an SDL function, 0 when the library is too old to have it
+func lookupFunc(lib uintptr, name string) uintptr {
+	fn, err := purego.Dlsym(lib, name)
+	if err != nil {
+		return 0
+	}
+	return fn
+}
@@ -0,0 +1,53 @@
+package sdlext
+
+import (
+	"syscall"
+)
+
+// Open the SDL library (already loaded by the sdl package, so this just returns its handle)
+func loadSDLLibrary() (uintptr, error) {
+	handle, err := syscall.LoadLibrary("SDL3.dll")
+	return uintptr(handle), err
+}
+
+// Helper function returning the address of an SDL function, 0 when the library is too old to have it
+func lookupFunc(lib uintptr, name string) uintptr {
+	fn, err := syscall.GetProcAddress(syscall.Handle(lib), name)
+	if err != nil {
+		return 0
+	}
+	return fn
+}
+
+var procSetWindowsMessageHook = syscall.NewLazyDLL("SDL3.dll").NewProc("SDL_SetWindowsMessageHook")
+
+// WindowsMessage is a Win32 MSG taken from the main thread's queue
+type WindowsMessage struct {
+	Hwnd    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	X, Y    int32
+}
+
+var (
+	windowsMessageHook     func(msg *WindowsMessage) bool
+	windowsMessageCallback = syscall.NewCallback(func(userdata uintptr, msg *WindowsMessage) uintptr {
+		if windowsMessageHook(msg) {
+			return 1
+		}
+		return 0
+	})
+)
+
+// SetWindowsMessageHook has hook see every message SDL pumps before it is
+// dispatched, returning false drops the message. nil removes the hook.
+func SetWindowsMessageHook(hook func(msg *WindowsMessage) bool) {
+	windowsMessageHook = hook
+	if hook == nil {
+		procSetWindowsMessageHook.Call(0, 0)
+		return
+	}
+	procSetWindowsMessageHook.Call(windowsMessageCallback, 0)
+}
@@ -0,0 +1,188 @@
+// animatedimage.go
+package ui
+
+// AnimatedImage widget playing GIF and APNG files (loading indicators,
+// demos). Frames are composited when the file is loaded, uploaded as one
+// texture each and shown for their own delay.
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Frames asking for less than minFrameDelay seconds are shown for
+// defaultFrameDelay instead, as browsers do
+const (
+	minFrameDelay     = 0.02
+	defaultFrameDelay = 0.1
+)
+
+// AnimatedImage widget showing an animated GIF or PNG
+type AnimatedImage struct {
+	Bounds   sdl.FRect
+	Opacity  float32
+	Playing  bool
+	Frame    int // Frame being shown
+	frames   []*sdl.Texture
+	delays   []float32 // Seconds each frame is shown
+	elapsed  float32   // Time spent on the current frame
+	path     string
+	renderer *sdl.Renderer
+	Dirty
+}
+
+// NewAnimatedImage loads a .gif or .png file shown in bounds and starts playing it.
+// A zero width or height is taken from the image size.
+func NewAnimatedImage(renderer *sdl.Renderer, path string, bounds sdl.FRect) (*AnimatedImage, error) {
+	anim := &AnimatedImage{Bounds: bounds, Opacity: 1, Playing: true, path: path, renderer: renderer}
+	if err := anim.load(); err != nil {
+		return nil, err
+	}
+	if anim.Bounds.W == 0 || anim.Bounds.H == 0 {
+		var w, h float32
+		sdl.GetTextureSize(anim.frames[0], &w, &h)
+		anim.Bounds.W, anim.Bounds.H = w, h
+	}
+	textureTracker.Track(anim, anim.load)
+	return anim, nil
+}
+
+// Decode the file and upload its frames (also used to recreate them after a device reset)
+func (a *AnimatedImage) load() error {
+	images, delays, err := decodeAnimation(a.path)
+	if err != nil {
+		return err
+	}
+	frames := make([]*sdl.Texture, 0, len(images))
+	for _, img := range images {
+		texture := textureFromImage(a.renderer, img)
+		if texture == nil {
+			for _, frame := range frames {
+				sdl.DestroyTexture(frame)
+			}
+			return errors.New(sdl.GetError())
+		}
+		frames = append(frames, texture)
+	}
+	a.destroyFrames()
+	a.frames = frames
+	a.delays = delays
+	for i, delay := range a.delays {
+		if delay < minFrameDelay {
+			a.delays[i] = defaultFrameDelay
+		}
+	}
+	a.Frame = min(a.Frame, len(a.frames)-1)
+	a.MarkDirty()
+	return nil
+}
+
+// Helper function decoding the composited frames and delays of an animation file
+func decodeAnimation(path string) ([]*image.NRGBA, []float32, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+	if strings.EqualFold(filepath.Ext(path), ".gif") {
+		return decodeGIF(file)
+	}
+	return decodeAPNG(file)
+}
+
+// Helper function decoding a GIF with its frame disposal applied
+func decodeGIF(r io.Reader) ([]*image.NRGBA, []float32, error) {
+	decoded, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	canvas := image.NewNRGBA(image.Rect(0, 0, decoded.Config.Width, decoded.Config.Height))
+	images := make([]*image.NRGBA, 0, len(decoded.Image))
+	delays := make([]float32, 0, len(decoded.Image))
+	for i, frame := range decoded.Image {
+		var disposal byte
+		if i < len(decoded.Disposal) {
+			disposal = decoded.Disposal[i]
+		}
+		var previous *image.NRGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneNRGBA(canvas)
+		}
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		images = append(images, cloneNRGBA(canvas))
+		delays = append(delays, float32(decoded.Delay[i])/100) // Hundredths of a second
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	if len(images) == 0 {
+		return nil, nil, errors.New("gif: no frames")
+	}
+	return images, delays, nil
+}
+
+// Tick advances playback by dt seconds, returns true while playing so the
+// loop keeps running (tick it through its Layout or app.App.OnTick)
+func (a *AnimatedImage) Tick(dt float32) bool {
+	if !a.Playing || len(a.frames) < 2 {
+		return false
+	}
+	a.elapsed += dt
+	for a.elapsed >= a.delays[a.Frame] {
+		a.elapsed -= a.delays[a.Frame]
+		a.Frame = (a.Frame + 1) % len(a.frames)
+		a.MarkDirty()
+	}
+	return true
+}
+
+// FrameCount returns the number of frames
+func (a *AnimatedImage) FrameCount() int {
+	return len(a.frames)
+}
+
+func (a *AnimatedImage) Update(event sdl.Event, mx, my float32) bool {
+	return false
+}
+
+func (a *AnimatedImage) Render(renderer *sdl.Renderer) {
+	if len(a.frames) == 0 {
+		return
+	}
+	defer PushOpacity(a.Opacity)()
+	drawTexture(renderer, a.frames[a.Frame], nil, &a.Bounds)
+}
+
+func (a *AnimatedImage) GetBounds() sdl.FRect {
+	return a.Bounds
+}
+
+func (a *AnimatedImage) SetPosition(x, y float32) {
+	a.Bounds.X, a.Bounds.Y = x, y
+}
+
+// Helper function freeing the frame textures
+func (a *AnimatedImage) destroyFrames() {
+	for _, frame := range a.frames {
+		sdl.DestroyTexture(frame)
+	}
+	a.frames = nil
+}
+
+// Destroy frees the frame textures
+func (a *AnimatedImage) Destroy() {
+	textureTracker.Untrack(a)
+	a.destroyFrames()
+}
@@ -0,0 +1,142 @@
+// animation.go
+package ui
+
+// Frame-based sprite animation, advanced with the main loop's delta time.
+
+// LoopMode selects what happens when an animation reaches its last frame
+type LoopMode int
+
+const (
+	LoopOnce     LoopMode = iota // Stop on the last frame
+	LoopRepeat                   // Start over from the first frame
+	LoopPingPong                 // Play backwards, then forwards again
+)
+
+// Animation sequences sprite sheet frames
+type Animation struct {
+	Frames    []int     // Sprite sheet frame indices
+	Durations []float32 // Seconds per frame (a single value applies to all frames)
+	Mode      LoopMode
+	Speed     float32 // Playback speed multiplier
+	Sprite    *Sprite // Sprite whose frame follows the animation (optional)
+	OnFinish  func()  // Called when a LoopOnce animation ends
+
+	current  int     // Position in Frames
+	elapsed  float32 // Time spent on the current frame
+	reverse  bool    // Playing backwards (ping-pong)
+	playing  bool
+	finished bool
+}
+
+// NewAnimation creates a playing animation showing every frame for frameDuration seconds
+func NewAnimation(frames []int, frameDuration float32, mode LoopMode) *Animation {
+	return &Animation{Frames: frames, Durations: []float32{frameDuration}, Mode: mode, Speed: 1, playing: true}
+}
+
+// Helper function returning how long frame i is shown
+func (a *Animation) duration(i int) float32 {
+	if len(a.Durations) == 0 {
+		return 0
+	}
+	if i < len(a.Durations) {
+		return a.Durations[i]
+	}
+	return a.Durations[len(a.Durations)-1]
+}
+
+// Frame returns the sprite sheet frame to show
+func (a *Animation) Frame() int {
+	if len(a.Frames) == 0 {
+		return 0
+	}
+	return a.Frames[a.current]
+}
+
+// Play resumes playback (restarting a finished animation)
+func (a *Animation) Play() {
+	if a.finished {
+		a.Seek(0)
+	}
+	a.playing = true
+}
+
+// Pause stops advancing, keeping the current frame
+func (a *Animation) Pause() {
+	a.playing = false
+}
+
+// Stop pauses and rewinds to the first frame
+func (a *Animation) Stop() {
+	a.playing = false
+	a.Seek(0)
+}
+
+// Seek jumps to a position in Frames
+func (a *Animation) Seek(index int) {
+	a.current = max(0, min(index, len(a.Frames)-1))
+	a.elapsed = 0
+	a.reverse = false
+	a.finished = false
+	a.syncSprite()
+}
+
+// Playing reports whether the animation is advancing
+func (a *Animation) Playing() bool {
+	return a.playing
+}
+
+// Finished reports whether a LoopOnce animation reached its end
+func (a *Animation) Finished() bool {
+	return a.finished
+}
+
+// Tick advances the animation by dt seconds, returns true while it is playing
+func (a *Animation) Tick(dt float32) bool {
+	if !a.playing || len(a.Frames) == 0 {
+		return false
+	}
+	a.elapsed += dt * a.Speed
+	for a.playing {
+		frameTime := a.duration(a.current)
+		if frameTime <= 0 || a.elapsed < frameTime {
+			break
+		}
+		a.elapsed -= frameTime
+		a.advance()
+	}
+	a.syncSprite()
+	return true // Also true on the finishing frame so it gets drawn
+}
+
+// Helper function stepping to the next frame according to the loop mode
+func (a *Animation) advance() {
+	last := len(a.Frames) - 1
+	step := 1
+	if a.reverse {
+		step = -1
+	}
+	next := a.current + step
+	switch {
+	case next >= 0 && next <= last:
+		a.current = next
+	case a.Mode == LoopRepeat:
+		a.current = 0
+	case a.Mode == LoopPingPong:
+		a.reverse = !a.reverse
+		a.current = max(0, min(a.current-step, last))
+	default:
+		a.playing = false
+		a.finished = true
+		a.elapsed = 0
+		if a.OnFinish != nil {
+			a.OnFinish()
+		}
+	}
+}
+
+// Helper function showing the current frame on the target sprite
+func (a *Animation) syncSprite() {
+	if a.Sprite != nil && len(a.Frames) > 0 {
+		a.Sprite.Frame = a.Frames[a.current]
+	}
+}
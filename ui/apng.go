@@ -0,0 +1,186 @@
+// apng.go
+package ui
+
+// Animated PNG decoding. Each APNG frame is rewritten as a standalone PNG
+// (the file's header chunks plus the frame's data) and decoded with
+// image/png, then composited onto the canvas as the frame control asks.
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// APNG frame disposal and blending
+const (
+	apngDisposeNone       = 0
+	apngDisposeBackground = 1
+	apngDisposePrevious   = 2
+	apngBlendOver         = 1
+)
+
+// One frame as described by its fcTL chunk
+type apngFrame struct {
+	bounds   image.Rectangle // Position on the canvas
+	delay    float32         // Seconds
+	dispose  byte
+	blend    byte
+	data     []byte // Concatenated image data
+	hasImage bool   // IDAT/fdAT data was found for this frame
+}
+
+// Helper function appending a PNG chunk with its length and checksum
+func appendPNGChunk(out []byte, kind string, data []byte) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(data)))
+	start := len(out)
+	out = append(out, kind...)
+	out = append(out, data...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out[start:]))
+}
+
+// decodeAPNG decodes the frames of an animated PNG composited to full canvases.
+// A PNG without animation gives one frame.
+func decodeAPNG(r io.Reader) ([]*image.NRGBA, []float32, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.HasPrefix(raw, pngSignature) {
+		return nil, nil, errors.New("apng: not a PNG file")
+	}
+
+	var header []byte // IHDR data
+	var shared []byte // Chunks every frame needs (palette, transparency, gamma...)
+	var frames []*apngFrame
+	animated := false
+	for pos := len(pngSignature); pos+12 <= len(raw); {
+		length := int(binary.BigEndian.Uint32(raw[pos:]))
+		if pos+12+length > len(raw) {
+			return nil, nil, errors.New("apng: truncated chunk")
+		}
+		kind := string(raw[pos+4 : pos+8])
+		data := raw[pos+8 : pos+8+length]
+		chunk := raw[pos : pos+12+length]
+		pos += 12 + length
+
+		switch kind {
+		case "IHDR":
+			header = data
+		case "acTL":
+			animated = true
+		case "fcTL":
+			if length < 26 {
+				return nil, nil, errors.New("apng: short fcTL chunk")
+			}
+			x, y := int(binary.BigEndian.Uint32(data[12:])), int(binary.BigEndian.Uint32(data[16:]))
+			w, h := int(binary.BigEndian.Uint32(data[4:])), int(binary.BigEndian.Uint32(data[8:]))
+			num, den := float32(binary.BigEndian.Uint16(data[20:])), float32(binary.BigEndian.Uint16(data[22:]))
+			if den == 0 {
+				den = 100
+			}
+			frames = append(frames, &apngFrame{
+				bounds:  image.Rect(x, y, x+w, y+h),
+				delay:   num / den,
+				dispose: data[24],
+				blend:   data[25],
+			})
+		case "IDAT":
+			// The default image is only a frame when an fcTL comes before it
+			if len(frames) > 0 {
+				frames[len(frames)-1].data = append(frames[len(frames)-1].data, data...)
+				frames[len(frames)-1].hasImage = true
+			}
+		case "fdAT":
+			if len(frames) > 0 && length > 4 {
+				frames[len(frames)-1].data = append(frames[len(frames)-1].data, data[4:]...)
+				frames[len(frames)-1].hasImage = true
+			}
+		case "IEND":
+		default:
+			if len(frames) == 0 {
+				shared = append(shared, chunk...)
+			}
+		}
+	}
+
+	if !animated || len(frames) == 0 || len(header) < 13 {
+		img, err := png.Decode(bytes.NewReader(raw))
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*image.NRGBA{toNRGBA(img)}, []float32{0}, nil
+	}
+
+	width, height := int(binary.BigEndian.Uint32(header[0:])), int(binary.BigEndian.Uint32(header[4:]))
+	canvas := image.NewNRGBA(image.Rect(0, 0, width, height))
+	var images []*image.NRGBA
+	var delays []float32
+	for i, frame := range frames {
+		if !frame.hasImage {
+			continue
+		}
+		img, err := png.Decode(bytes.NewReader(frame.pngFile(header, shared)))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var previous *image.NRGBA
+		dispose := frame.dispose
+		if dispose == apngDisposePrevious && i == 0 {
+			dispose = apngDisposeBackground // Nothing to go back to
+		}
+		if dispose == apngDisposePrevious {
+			previous = cloneNRGBA(canvas)
+		}
+		op := draw.Src
+		if frame.blend == apngBlendOver {
+			op = draw.Over
+		}
+		draw.Draw(canvas, frame.bounds, img, image.Point{}, op)
+		images = append(images, cloneNRGBA(canvas))
+		delays = append(delays, frame.delay)
+
+		switch dispose {
+		case apngDisposeBackground:
+			draw.Draw(canvas, frame.bounds, image.Transparent, image.Point{}, draw.Src)
+		case apngDisposePrevious:
+			canvas = previous
+		}
+	}
+	return images, delays, nil
+}
+
+// Helper function building a standalone PNG file holding just this frame
+func (f *apngFrame) pngFile(header, shared []byte) []byte {
+	ihdr := bytes.Clone(header)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(f.bounds.Dx()))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(f.bounds.Dy()))
+
+	out := bytes.Clone(pngSignature)
+	out = appendPNGChunk(out, "IHDR", ihdr)
+	out = append(out, shared...)
+	out = appendPNGChunk(out, "IDAT", f.data)
+	return appendPNGChunk(out, "IEND", nil)
+}
+
+// Helper function converting any image to straight alpha RGBA
+func toNRGBA(img image.Image) *image.NRGBA {
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		return nrgba
+	}
+	nrgba := image.NewNRGBA(img.Bounds())
+	draw.Draw(nrgba, nrgba.Rect, img, img.Bounds().Min, draw.Src)
+	return nrgba
+}
+
+// Helper function copying an image
+func cloneNRGBA(img *image.NRGBA) *image.NRGBA {
+	return &image.NRGBA{Pix: bytes.Clone(img.Pix), Stride: img.Stride, Rect: img.Rect}
+}
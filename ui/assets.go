@@ -0,0 +1,176 @@
+// assets.go
+package ui
+
+// Asset manager: fonts, images and sounds loaded by name from a list of
+// file systems (directories, files built in with go:embed), cached and
+// reference counted so every asset is read once and freed when the last
+// user releases it.
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// AssetManager loads assets from Sources, the first one holding a name wins
+type AssetManager struct {
+	Sources  []fs.FS
+	Renderer *sdl.Renderer // Needed for textures
+	entries  map[string]*assetEntry
+}
+
+// A cached asset and its users
+type assetEntry struct {
+	refs    int
+	data    []byte // File contents (fonts read from them while open)
+	font    *ttf.Font
+	texture *sdl.Texture
+}
+
+func NewAssetManager(sources ...fs.FS) *AssetManager {
+	return &AssetManager{Sources: sources, entries: make(map[string]*assetEntry)}
+}
+
+// ReadFile returns the contents of the named asset. Names use forward
+// slashes (e.g. "images/logo.png"), absolute paths are read from disk.
+func (m *AssetManager) ReadFile(name string) ([]byte, error) {
+	if filepath.IsAbs(name) {
+		return os.ReadFile(name)
+	}
+	for _, source := range m.Sources {
+		data, err := fs.ReadFile(source, name)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("asset %q: %w", name, fs.ErrNotExist)
+}
+
+// Open opens the named asset to read it in parts (e.g. streamed music), the
+// caller closes it. Names are looked up like ReadFile.
+func (m *AssetManager) Open(name string) (fs.File, error) {
+	if filepath.IsAbs(name) {
+		return os.Open(name)
+	}
+	for _, source := range m.Sources {
+		file, err := source.Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("asset %q: %w", name, fs.ErrNotExist)
+}
+
+// Font opens the named font at size (points), shared with other users of the same size
+func (m *AssetManager) Font(name string, size float32) (*ttf.Font, error) {
+	entry, err := m.acquire(fontKey(name, size), name, func(entry *assetEntry) error {
+		entry.font = ttf.OpenFontIO(sdl.IOFromConstMem(entry.data), true, size)
+		if entry.font == nil {
+			return fmt.Errorf("asset %q: %s", name, sdl.GetError())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.font, nil
+}
+
+// Texture loads the named image (BMP, PNG or JPEG) into a shared texture
+func (m *AssetManager) Texture(name string) (*sdl.Texture, error) {
+	entry, err := m.acquire("texture:"+name, name, func(entry *assetEntry) error {
+		entry.texture = textureFromData(m.Renderer, name, entry.data)
+		entry.data = nil // Only fonts keep reading their file
+		if entry.texture == nil {
+			return fmt.Errorf("asset %q: %s", name, sdl.GetError())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry.texture, nil
+}
+
+// Sound returns the contents of the named sound file, shared between users
+func (m *AssetManager) Sound(name string) ([]byte, error) {
+	entry, err := m.acquire("sound:"+name, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return entry.data, nil
+}
+
+// ReleaseFont, ReleaseTexture and ReleaseSound drop one reference taken by
+// Font, Texture and Sound, the asset is freed when none are left
+func (m *AssetManager) ReleaseFont(name string, size float32) { m.release(fontKey(name, size)) }
+func (m *AssetManager) ReleaseTexture(name string)            { m.release("texture:" + name) }
+func (m *AssetManager) ReleaseSound(name string)              { m.release("sound:" + name) }
+
+// Destroy frees every asset, whether released or not
+func (m *AssetManager) Destroy() {
+	for key, entry := range m.entries {
+		entry.free()
+		delete(m.entries, key)
+	}
+}
+
+// Helper function returning the cache key of a font
+func fontKey(name string, size float32) string {
+	return fmt.Sprintf("font:%s@%g", name, size)
+}
+
+// Helper function returning the cached entry for key with one more
+// reference, reading name and calling create (may be nil) on first use
+func (m *AssetManager) acquire(key, name string, create func(entry *assetEntry) error) (*assetEntry, error) {
+	if entry, ok := m.entries[key]; ok {
+		entry.refs++
+		return entry, nil
+	}
+	data, err := m.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	entry := &assetEntry{refs: 1, data: data}
+	if create != nil {
+		if err := create(entry); err != nil {
+			return nil, err
+		}
+	}
+	m.entries[key] = entry
+	return entry, nil
+}
+
+// Helper function dropping a reference
+func (m *AssetManager) release(key string) {
+	entry, ok := m.entries[key]
+	if !ok {
+		return
+	}
+	entry.refs--
+	if entry.refs <= 0 {
+		entry.free()
+		delete(m.entries, key)
+	}
+}
+
+// Helper function freeing what an entry holds
+func (e *assetEntry) free() {
+	if e.font != nil {
+		ttf.CloseFont(e.font)
+	}
+	if e.texture != nil {
+		sdl.DestroyTexture(e.texture)
+	}
+}
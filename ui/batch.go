@@ -0,0 +1,159 @@
+// batch.go
+package ui
+
+// Render batching: solid rectangles and texture quads are collected into
+// vertex lists and drawn with one RenderGeometry call per run of the same
+// texture, instead of one draw call per fill or texture. Quads are drawn with
+// the blend mode current at Flush. Layouts render their widgets into a shared
+// batch (BeginBatch), so the drawing helpers used by widgets are batched too.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// RenderBatch accumulates quads until Flush (draw order is kept)
+type RenderBatch struct {
+	DrawCalls int // Draw calls issued by Flush since the last ResetStats
+
+	renderer *sdl.Renderer
+	texture  *sdl.Texture // Texture of the pending quads (nil for solid fills)
+	vertices []sdl.Vertex
+	indices  []int32
+}
+
+func NewRenderBatch(renderer *sdl.Renderer) *RenderBatch {
+	return &RenderBatch{renderer: renderer}
+}
+
+// AddQuad queues a quad with corners clockwise from the top-left and matching texture coordinates (0 to 1)
+func (b *RenderBatch) AddQuad(texture *sdl.Texture, corners, uvs [4]sdl.FPoint, color sdl.Color) {
+	if texture != b.texture {
+		b.Flush() // A different texture needs its own draw call
+		b.texture = texture
+	}
+	base := int32(len(b.vertices))
+	fcolor := toFColor(color)
+	for i := range corners {
+		b.vertices = append(b.vertices, sdl.Vertex{Position: corners[i], Color: fcolor, TexCoord: uvs[i]})
+	}
+	b.indices = append(b.indices, base, base+1, base+2, base, base+2, base+3)
+}
+
+// AddGeometry queues triangles, indices refer to vertices (nil: consecutive triples)
+func (b *RenderBatch) AddGeometry(texture *sdl.Texture, vertices []sdl.Vertex, indices []int32) {
+	if texture != b.texture {
+		b.Flush()
+		b.texture = texture
+	}
+	base := int32(len(b.vertices))
+	b.vertices = append(b.vertices, vertices...)
+	if indices == nil {
+		for i := range int32(len(vertices)) {
+			b.indices = append(b.indices, base+i)
+		}
+		return
+	}
+	for _, index := range indices {
+		b.indices = append(b.indices, base+index)
+	}
+}
+
+// FillRect queues a solid rectangle
+func (b *RenderBatch) FillRect(rect sdl.FRect, color sdl.Color) {
+	b.AddQuad(nil, rectCorners(rect), [4]sdl.FPoint{}, color)
+}
+
+// Texture queues a region of a texture (src in texture pixels, nil for all of it), tinted by color
+func (b *RenderBatch) Texture(texture *sdl.Texture, src *sdl.FRect, dst sdl.FRect, color sdl.Color) {
+	b.AddQuad(texture, rectCorners(dst), textureUVs(texture, src), color)
+}
+
+// Flush draws the queued quads
+func (b *RenderBatch) Flush() {
+	if len(b.indices) == 0 {
+		return
+	}
+	// Solid fills use the draw blend mode, textured quads the texture's
+	if b.texture == nil {
+		sdl.SetRenderDrawBlendMode(b.renderer, drawBlendMode)
+	} else {
+		sdl.SetTextureBlendMode(b.texture, drawBlendMode)
+	}
+	sdl.RenderGeometry(b.renderer, b.texture, b.vertices, b.indices)
+	b.DrawCalls++
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
+
+// Batch shared by the drawing helpers between BeginBatch and EndBatch
+var (
+	frameBatch *RenderBatch
+	batching   int // Depth of nested BeginBatch calls
+)
+
+// BeginBatch makes the drawing helpers (FillRect, the shapes, text, images)
+// queue into a shared batch until the matching EndBatch, so consecutive fills
+// and draws of the same texture become one draw call. Calls nest, the
+// outermost EndBatch draws. Code drawing with SDL directly in between calls
+// FlushBatch first (SetDrawColor does).
+func BeginBatch(renderer *sdl.Renderer) {
+	if batching == 0 && (frameBatch == nil || frameBatch.renderer != renderer) {
+		frameBatch = NewRenderBatch(renderer)
+	}
+	batching++
+}
+
+// EndBatch ends a BeginBatch, drawing the queued quads when it was the outermost
+func EndBatch() {
+	if batching == 0 {
+		return
+	}
+	if batching == 1 {
+		frameBatch.Flush()
+	}
+	batching--
+}
+
+// FlushBatch draws the quads queued so far, needed before drawing with SDL
+// directly or changing the render target, clip rectangle or viewport
+func FlushBatch() {
+	if batching > 0 {
+		frameBatch.Flush()
+	}
+}
+
+// BatchDrawCalls returns the draw calls made by the shared batch and resets
+// the count, e.g. once per frame for statistics
+func BatchDrawCalls() int {
+	if frameBatch == nil {
+		return 0
+	}
+	calls := frameBatch.DrawCalls
+	frameBatch.ResetStats()
+	return calls
+}
+
+// ResetStats clears the draw call counter (e.g. at the start of a frame)
+func (b *RenderBatch) ResetStats() {
+	b.DrawCalls = 0
+}
+
+// Helper function returning the corners of a rectangle clockwise from the top-left
+func rectCorners(rect sdl.FRect) [4]sdl.FPoint {
+	return [4]sdl.FPoint{
+		{X: rect.X, Y: rect.Y},
+		{X: rect.X + rect.W, Y: rect.Y},
+		{X: rect.X + rect.W, Y: rect.Y + rect.H},
+		{X: rect.X, Y: rect.Y + rect.H},
+	}
+}
+
+// Helper function returning the texture coordinates of a region (nil for the whole texture)
+func textureUVs(texture *sdl.Texture, src *sdl.FRect) [4]sdl.FPoint {
+	if src == nil {
+		return rectCorners(sdl.FRect{W: 1, H: 1})
+	}
+	var texW, texH float32
+	sdl.GetTextureSize(texture, &texW, &texH)
+	return rectCorners(sdl.FRect{X: src.X / texW, Y: src.Y / texH, W: src.W / texW, H: src.H / texH})
+}
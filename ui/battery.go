@@ -0,0 +1,89 @@
+// battery.go
+package ui
+
+// Battery indicator for status bars: a battery outline filled to the charge
+// level, red when low, with a bolt while charging (see app.OnPowerChanged)
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Charge (percent) below which the battery is drawn in LowColor
+const batteryLowPercent = 20
+
+// BatteryIndicator widget
+type BatteryIndicator struct {
+	Bounds   sdl.FRect
+	Percent  int32 // 0 to 100, -1 draws an empty outline
+	Charging bool
+	Color    sdl.Color
+	LowColor sdl.Color
+	Opacity  float32 // 0 (invisible) to 1 (opaque)
+	Dirty
+}
+
+func NewBatteryIndicator(x, y, w, h float32) *BatteryIndicator {
+	return &BatteryIndicator{
+		Bounds:   sdl.FRect{X: x, Y: y, W: w, H: h},
+		Percent:  -1,
+		Color:    sdl.Color{R: 230, G: 230, B: 230, A: 255},
+		LowColor: sdl.Color{R: 220, G: 60, B: 60, A: 255},
+		Opacity:  1,
+	}
+}
+
+// SetState changes the shown charge and charging state
+func (b *BatteryIndicator) SetState(percent int32, charging bool) {
+	if percent == b.Percent && charging == b.Charging {
+		return
+	}
+	b.Percent, b.Charging = min(percent, 100), charging
+	b.MarkDirty()
+}
+
+func (b *BatteryIndicator) Update(event sdl.Event, mx, my float32) bool {
+	return false
+}
+
+func (b *BatteryIndicator) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(b.Opacity)()
+	color := b.Color
+	if b.Percent >= 0 && b.Percent < batteryLowPercent && !b.Charging {
+		color = b.LowColor
+	}
+
+	// Body with the terminal nub on the right
+	nubW := max(b.Bounds.W*0.08, 2)
+	body := sdl.FRect{X: b.Bounds.X, Y: b.Bounds.Y, W: b.Bounds.W - nubW, H: b.Bounds.H}
+	nub := sdl.FRect{X: body.X + body.W, Y: body.Y + body.H*0.3, W: nubW, H: body.H * 0.4}
+	DrawRoundedRect(renderer, body, 3, 1.5, color)
+	FillRoundedRect(renderer, nub, 1, color)
+
+	if b.Percent > 0 {
+		inset := float32(3)
+		fill := sdl.FRect{X: body.X + inset, Y: body.Y + inset, W: (body.W - 2*inset) * float32(b.Percent) / 100, H: body.H - 2*inset}
+		FillRoundedRect(renderer, fill, 1, color)
+	}
+	if b.Charging {
+		// Lightning bolt across the middle, dark so it shows on the fill
+		cx, cy, w, h := body.X+body.W/2, body.Y+body.H/2, body.H*0.35, body.H*0.4
+		bolt := []sdl.FPoint{
+			{X: cx + w*0.2, Y: cy - h},
+			{X: cx - w*0.6, Y: cy + h*0.15},
+			{X: cx - w*0.05, Y: cy + h*0.15},
+			{X: cx - w*0.2, Y: cy + h},
+			{X: cx + w*0.6, Y: cy - h*0.15},
+			{X: cx + w*0.05, Y: cy - h*0.15},
+		}
+		FillPolygon(renderer, bolt, sdl.Color{R: 40, G: 40, B: 40, A: 255})
+		DrawPolygon(renderer, bolt, 1, color)
+	}
+}
+
+func (b *BatteryIndicator) GetBounds() sdl.FRect {
+	return b.Bounds
+}
+
+func (b *BatteryIndicator) SetPosition(x, y float32) {
+	b.Bounds.X, b.Bounds.Y = x, y
+}
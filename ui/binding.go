@@ -0,0 +1,51 @@
+// binding.go
+package ui
+
+// Data binding: widgets bound to Go values follow them by themselves. Bound
+// values are compared in Tick (layouts tick their widgets every frame), so
+// plain variables work without wrapping them in observable types.
+
+import (
+	"fmt"
+)
+
+// BindText makes the label show the result of text, re-rendered whenever it changes
+func (l *Label) BindText(text func() string) {
+	l.binding = text
+	l.Tick(0)
+}
+
+// BindLabel makes label show *value formatted with format (e.g. "Counter: %d")
+func BindLabel[T any](label *Label, value *T, format string) {
+	label.BindText(func() string {
+		return fmt.Sprintf(format, *value)
+	})
+}
+
+// Tick re-renders a bound label whose value changed, returns true if it did
+func (l *Label) Tick(dt float32) bool {
+	if l.binding == nil {
+		return false
+	}
+	if text := l.binding(); text != l.Text {
+		l.UpdateText(text)
+		return true
+	}
+	return false
+}
+
+// BindTextInput keeps the input and *value in sync both ways: edits are
+// written to *value and outside changes to *value replace the input text
+func BindTextInput(input *TextInput, value *string) {
+	input.bound = value
+	input.Tick(0)
+}
+
+// Tick picks up outside changes of a bound value, returns true if the text was replaced
+func (t *TextInput) Tick(dt float32) bool {
+	if t.bound == nil || *t.bound == t.Text {
+		return false
+	}
+	t.SetText(*t.bound)
+	return true
+}
@@ -0,0 +1,29 @@
+// blend.go
+package ui
+
+// Blend mode selection for widgets and drawing helpers. Everything is alpha
+// blended by default, PushBlendMode switches to e.g. additive (glows) or
+// multiply (shading) for the drawing that follows.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Blend mode applied by the drawing helpers
+var drawBlendMode sdl.BlendMode = sdl.BlendModeBlend
+
+// PushBlendMode sets the blend mode used by the drawing helpers and returns
+// a function restoring the previous one, e.g. `defer PushBlendMode(sdl.BlendModeAdd)()`
+func PushBlendMode(mode sdl.BlendMode) func() {
+	previous := drawBlendMode
+	if mode != previous {
+		FlushBatch() // Queued quads use the mode current at Flush
+	}
+	drawBlendMode = mode
+	return func() {
+		if drawBlendMode != previous {
+			FlushBatch()
+		}
+		drawBlendMode = previous
+	}
+}
@@ -0,0 +1,146 @@
+// camera.go
+package ui
+
+// 2D camera for the "world layer" (the draggable square, sprites, tilemaps):
+// world coordinates are panned and zoomed into a viewport, while UI widgets
+// keep drawing in screen space.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// Camera2D maps world coordinates to a screen viewport
+type Camera2D struct {
+	X, Y     float32 // World position shown at the viewport's top-left corner
+	Zoom     float32 // Screen units per world unit
+	MinZoom  float32
+	MaxZoom  float32
+	Viewport sdl.FRect // Screen area of the world layer (zero size for the whole window)
+
+	// Viewport active before Begin
+	previous    sdl.Rect
+	hadViewport bool
+}
+
+func NewCamera2D() *Camera2D {
+	return &Camera2D{Zoom: 1, MinZoom: 0.25, MaxZoom: 8}
+}
+
+// Helper function returning the viewport size, the whole output if Viewport is empty
+func (c *Camera2D) viewportSize(renderer *sdl.Renderer) (float32, float32) {
+	if c.Viewport.W > 0 && c.Viewport.H > 0 {
+		return c.Viewport.W, c.Viewport.H
+	}
+	var w, h int32
+	sdl.GetCurrentRenderOutputSize(renderer, &w, &h)
+	return float32(w) / pixelDensity, float32(h) / pixelDensity
+}
+
+// View returns the world area visible in the viewport
+func (c *Camera2D) View(renderer *sdl.Renderer) sdl.FRect {
+	w, h := c.viewportSize(renderer)
+	return sdl.FRect{X: c.X, Y: c.Y, W: w / c.Zoom, H: h / c.Zoom}
+}
+
+// Begin restricts drawing to the viewport, world layer drawing goes between Begin and End
+func (c *Camera2D) Begin(renderer *sdl.Renderer) {
+	if c.Viewport.W <= 0 || c.Viewport.H <= 0 {
+		return // Whole output, nothing to change
+	}
+	FlushBatch()
+	c.hadViewport = sdl.RenderViewportSet(renderer)
+	sdl.GetRenderViewport(renderer, &c.previous)
+	viewport := sdl.Rect{X: int32(c.Viewport.X), Y: int32(c.Viewport.Y), W: int32(c.Viewport.W), H: int32(c.Viewport.H)}
+	sdl.SetRenderViewport(renderer, &viewport)
+}
+
+// End restores the screen-space viewport for the UI layer
+func (c *Camera2D) End(renderer *sdl.Renderer) {
+	if c.Viewport.W <= 0 || c.Viewport.H <= 0 {
+		return
+	}
+	FlushBatch()
+	if c.hadViewport {
+		sdl.SetRenderViewport(renderer, &c.previous)
+	} else {
+		sdl.SetRenderViewport(renderer, nil) // Keep following the output size
+	}
+}
+
+// WorldToView converts a world position to drawing coordinates inside Begin/End
+func (c *Camera2D) WorldToView(x, y float32) (float32, float32) {
+	return (x - c.X) * c.Zoom, (y - c.Y) * c.Zoom
+}
+
+// WorldRectToView converts a world rectangle to drawing coordinates inside Begin/End
+func (c *Camera2D) WorldRectToView(rect sdl.FRect) sdl.FRect {
+	x, y := c.WorldToView(rect.X, rect.Y)
+	return sdl.FRect{X: x, Y: y, W: rect.W * c.Zoom, H: rect.H * c.Zoom}
+}
+
+// ScreenToWorld converts a window position (e.g. the mouse) to world coordinates
+func (c *Camera2D) ScreenToWorld(x, y float32) (float32, float32) {
+	return (x-c.Viewport.X)/c.Zoom + c.X, (y-c.Viewport.Y)/c.Zoom + c.Y
+}
+
+// Pan moves the camera by a distance in screen units (e.g. a mouse drag)
+func (c *Camera2D) Pan(dx, dy float32) {
+	c.X -= dx / c.Zoom
+	c.Y -= dy / c.Zoom
+}
+
+// ZoomAt multiplies the zoom by factor keeping the world point under the
+// window position x, y in place (e.g. zooming towards the mouse)
+func (c *Camera2D) ZoomAt(factor, x, y float32) {
+	worldX, worldY := c.ScreenToWorld(x, y)
+	c.Zoom = max(c.MinZoom, min(c.Zoom*factor, c.MaxZoom))
+	c.X = worldX - (x-c.Viewport.X)/c.Zoom
+	c.Y = worldY - (y-c.Viewport.Y)/c.Zoom
+}
+
+// ApplyGesture zooms with pinches and pans with two-finger pans, returns
+// true if the gesture was one of them
+func (c *Camera2D) ApplyGesture(gesture Gesture) bool {
+	switch gesture.Kind {
+	case GesturePinch:
+		c.ZoomAt(gesture.Scale, gesture.X, gesture.Y)
+	case GesturePan:
+		c.Pan(gesture.DX, gesture.DY)
+	default:
+		return false
+	}
+	return true
+}
+
+// CenterOn moves the camera so the world point x, y is at the viewport center
+func (c *Camera2D) CenterOn(renderer *sdl.Renderer, x, y float32) {
+	view := c.View(renderer)
+	c.X = x - view.W/2
+	c.Y = y - view.H/2
+}
+
+// DrawText draws text at the world position x, y through the camera (call
+// between Begin and End). It is rendered at the zoomed size by the active
+// text backend, see SetTextBackend. Returns the drawn size in view units.
+func (c *Camera2D) DrawText(renderer *sdl.Renderer, font *ttf.Font, text string, x, y float32, color sdl.Color) (float32, float32) {
+	viewX, viewY := c.WorldToView(x, y)
+	return DrawTextScaled(renderer, font, text, viewX, viewY, c.Zoom, color)
+}
+
+// RenderWorld draws the sprite through the camera (call between Begin and End)
+func (s *Sprite) RenderWorld(renderer *sdl.Renderer, camera *Camera2D) {
+	screen := *s
+	screen.X, screen.Y = camera.WorldToView(s.X, s.Y)
+	screen.W, screen.H = s.Size()
+	screen.W *= camera.Zoom
+	screen.H *= camera.Zoom
+	screen.OriginX *= camera.Zoom
+	screen.OriginY *= camera.Zoom
+	screen.Render(renderer)
+}
+
+// RenderWorld draws the visible part of the map through the camera (call between Begin and End)
+func (m *Tilemap) RenderWorld(renderer *sdl.Renderer, camera *Camera2D) {
+	m.Render(renderer, camera.View(renderer), camera.Zoom)
+}
@@ -0,0 +1,48 @@
+// clicks.go
+package ui
+
+// Click counting for double (and triple) clicks, from the timestamps and
+// positions of mouse button presses
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Presses closer than this in time (nanoseconds) and space (logical units)
+// continue a multi-click
+const (
+	DoubleClickTime     = 500_000_000
+	DoubleClickDistance = 4
+)
+
+// ClickCounter counts consecutive clicks of the same button at about the same place
+type ClickCounter struct {
+	count  int
+	last   uint64 // Timestamp of the last press
+	x, y   float32
+	button uint8
+}
+
+// Click registers a button press, returns 1 for a single click, 2 for a
+// double click and so on. Other events return 0.
+func (c *ClickCounter) Click(event sdl.Event) int {
+	if event.Type() != sdl.EventMouseButtonDown {
+		return 0
+	}
+	press := event.Button()
+	dx, dy := press.X-c.x, press.Y-c.y
+	if c.count > 0 && press.Button == c.button &&
+		press.Timestamp-c.last <= DoubleClickTime &&
+		dx*dx+dy*dy <= DoubleClickDistance*DoubleClickDistance {
+		c.count++
+	} else {
+		c.count = 1
+	}
+	c.last, c.x, c.y, c.button = press.Timestamp, press.X, press.Y, press.Button
+	return c.count
+}
+
+// Reset makes the next press a single click
+func (c *ClickCounter) Reset() {
+	c.count = 0
+}
@@ -0,0 +1,140 @@
+// clipboard.go
+package ui
+
+// System clipboard text and images, shared with other applications. The App
+// reports changes made by anyone with OnClipboardChanged.
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"log/slog"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// GetClipboardText returns the text on the clipboard, "" if there is none
+func GetClipboardText() string {
+	if !sdlext.HasClipboardText() {
+		return ""
+	}
+	return sdl.GetClipboardText()
+}
+
+// SetClipboardText puts text on the clipboard, returns false on failure
+func SetClipboardText(text string) bool {
+	if !sdlext.SetClipboardText(text) {
+		slog.Warn("clipboard text not set", "error", sdl.GetError())
+		return false
+	}
+	return true
+}
+
+// HasClipboardText checks whether the clipboard holds non-empty text
+func HasClipboardText() bool {
+	return sdlext.HasClipboardText()
+}
+
+// Image formats read from the clipboard, in order of preference. Windows
+// offers images as BMP, the other systems as PNG.
+var clipboardImageTypes = []string{"image/png", "image/bmp", "image/jpeg"}
+
+// HasClipboardImage checks whether the clipboard holds an image
+func HasClipboardImage() bool {
+	for _, mimeType := range clipboardImageTypes {
+		if sdlext.HasClipboardData(mimeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClipboardImage returns the image on the clipboard, false if there is none
+func GetClipboardImage() (image.Image, bool) {
+	for _, mimeType := range clipboardImageTypes {
+		if !sdlext.HasClipboardData(mimeType) {
+			continue
+		}
+		data := sdlext.GetClipboardData(mimeType)
+		if len(data) == 0 {
+			continue
+		}
+		img, err := decodeImage("clipboard."+mimeType[len("image/"):], data)
+		if err != nil {
+			slog.Warn("clipboard image not read", "type", mimeType, "error", err)
+			continue
+		}
+		return img, true
+	}
+	return nil, false
+}
+
+// SetClipboardImage puts an image on the clipboard (as PNG and BMP), returns
+// false on failure
+func SetClipboardImage(img image.Image) bool {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		slog.Warn("clipboard image not set", "error", err)
+		return false
+	}
+	data := map[string][]byte{"image/png": encoded.Bytes()}
+	if bmp := encodeBMP(toNRGBA(img)); bmp != nil {
+		data["image/bmp"] = bmp
+	}
+	if !sdlext.SetClipboardData(data) {
+		slog.Warn("clipboard image not set", "error", sdl.GetError())
+		return false
+	}
+	return true
+}
+
+// Helper function encoding an image as a BMP file with SDL, nil on failure
+func encodeBMP(img *image.NRGBA) []byte {
+	surface := surfaceFromNRGBA(img)
+	if surface == nil {
+		return nil
+	}
+	defer sdl.DestroySurface(surface)
+	// Pixels plus room for the headers, the file header has the written size
+	size := img.Rect.Size()
+	buffer := make([]byte, size.X*size.Y*4+1024)
+	if !sdl.SaveBMPIO(surface, sdl.IOFromMem(buffer), true) {
+		return nil
+	}
+	return buffer[:binary.LittleEndian.Uint32(buffer[2:6])]
+}
+
+// Helper function copying an image into a new surface (the caller destroys it), nil on failure
+func surfaceFromNRGBA(img *image.NRGBA) *sdl.Surface {
+	size := img.Rect.Size()
+	surface := sdl.CreateSurface(int32(size.X), int32(size.Y), sdl.PixelFormatRGBA32)
+	if surface == nil {
+		return nil
+	}
+	// Copied row by row, the surface rows may be padded
+	dst := unsafe.Slice((*byte)(surface.Pixels), int(surface.Pitch)*size.Y)
+	for y := 0; y < size.Y; y++ {
+		copy(dst[y*int(surface.Pitch):], img.Pix[y*img.Stride:y*img.Stride+size.X*4])
+	}
+	return surface
+}
+
+// Helper function copying a surface into an image, nil on failure
+func nrgbaFromSurface(surface *sdl.Surface) *image.NRGBA {
+	converted := sdl.ConvertSurface(surface, sdl.PixelFormatRGBA32)
+	if converted == nil {
+		return nil
+	}
+	defer sdl.DestroySurface(converted)
+	w, h := int(converted.W), int(converted.H)
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	src := unsafe.Slice((*byte)(converted.Pixels), int(converted.Pitch)*h)
+	for y := 0; y < h; y++ {
+		copy(img.Pix[y*img.Stride:], src[y*int(converted.Pitch):y*int(converted.Pitch)+w*4])
+	}
+	return img
+}
@@ -0,0 +1,121 @@
+// cursor.go
+package ui
+
+// Mouse cursor that follows the widget under the mouse: a hand over buttons,
+// an I-beam over text, resize arrows over whatever asks for them.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// CursorProvider is implemented by widgets wanting a cursor other than the
+// arrow while the mouse is over them (e.g. a split pane returning
+// sdl.SystemCursorEWResize over its divider)
+type CursorProvider interface {
+	MouseCursor(mx, my float32) sdl.SystemCursor
+}
+
+// CursorManager sets the system cursor for the widget under the mouse,
+// searching Roots the way clicks are delivered (topmost, innermost first)
+type CursorManager struct {
+	Roots   []Widget
+	current sdl.SystemCursor
+	held    bool // Hold overrides the widgets
+	cursors map[sdl.SystemCursor]*sdl.Cursor
+}
+
+func NewCursorManager(roots ...Widget) *CursorManager {
+	return &CursorManager{Roots: roots, cursors: make(map[sdl.SystemCursor]*sdl.Cursor)}
+}
+
+// Update picks the cursor on mouse motion, it never uses up the event
+func (c *CursorManager) Update(event sdl.Event, mx, my float32) bool {
+	if event.Type() == sdl.EventMouseMotion && !c.held {
+		c.set(cursorAt(c.Roots, sdl.FPoint{X: mx, Y: my}))
+	}
+	return false
+}
+
+// Hold shows cursor whatever is under the mouse, e.g. sdl.SystemCursorMove
+// while dragging, until Release
+func (c *CursorManager) Hold(cursor sdl.SystemCursor) {
+	c.held = true
+	c.set(cursor)
+}
+
+// Release goes back to the cursor of the widget under the mouse
+func (c *CursorManager) Release() {
+	if !c.held {
+		return
+	}
+	c.held = false
+	mx, my := float32(0), float32(0)
+	sdl.GetMouseState(&mx, &my)
+	c.set(cursorAt(c.Roots, sdl.FPoint{X: mx, Y: my}))
+}
+
+// Helper function switching the system cursor, creating it on first use
+func (c *CursorManager) set(id sdl.SystemCursor) {
+	if id == c.current {
+		return
+	}
+	cursor, ok := c.cursors[id]
+	if !ok {
+		cursor = sdl.CreateSystemCursor(id)
+		if cursor == nil {
+			return // Not available on this platform, keep the current one
+		}
+		c.cursors[id] = cursor
+	}
+	sdl.SetCursor(cursor)
+	c.current = id
+}
+
+// Helper function returning the cursor of the innermost widget containing
+// point that provides one, or the default arrow
+func cursorAt(widgets []Widget, point sdl.FPoint) sdl.SystemCursor {
+	for n := len(widgets) - 1; n >= 0; n-- { // Last drawn is on top
+		bounds := widgets[n].GetBounds()
+		if !sdl.PointInRectFloat(point, bounds) {
+			continue
+		}
+		if cursor := cursorAt(widgetChildren(widgets[n]), point); cursor != sdl.SystemCursorDefault {
+			return cursor
+		}
+		if provider, ok := widgets[n].(CursorProvider); ok {
+			return provider.MouseCursor(point.X, point.Y)
+		}
+		return sdl.SystemCursorDefault
+	}
+	return sdl.SystemCursorDefault
+}
+
+// Destroy frees the cursors and goes back to the default one
+func (c *CursorManager) Destroy() {
+	sdl.SetCursor(sdl.GetDefaultCursor())
+	for id, cursor := range c.cursors {
+		sdl.DestroyCursor(cursor)
+		delete(c.cursors, id)
+	}
+	c.current = sdl.SystemCursorDefault
+}
+
+// Cursors of the built-in widgets
+
+func (b *Button) MouseCursor(mx, my float32) sdl.SystemCursor {
+	if b.Disabled {
+		return sdl.SystemCursorDefault
+	}
+	return sdl.SystemCursorPointer
+}
+
+func (t *TextInput) MouseCursor(mx, my float32) sdl.SystemCursor {
+	return sdl.SystemCursorText
+}
+
+func (l *Label) MouseCursor(mx, my float32) sdl.SystemCursor {
+	if l.Selectable && !l.Markup {
+		return sdl.SystemCursorText
+	}
+	return sdl.SystemCursorDefault
+}
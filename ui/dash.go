@@ -0,0 +1,88 @@
+// dash.go
+package ui
+
+// Dashed and dotted lines for selection marquees, focus rectangles and chart
+// gridlines. The pattern runs continuously around corners, and animating the
+// offset gives "marching ants".
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Common patterns, alternating on and off lengths
+var (
+	PatternDashed = []float32{6, 4}
+	PatternDotted = []float32{1, 3}
+)
+
+// DrawDashedPath draws a polyline with a dash pattern starting offset units into the pattern
+func DrawDashedPath(renderer *sdl.Renderer, points []sdl.FPoint, closed bool, thickness float32, pattern []float32, offset float32, color sdl.Color) {
+	if len(points) < 2 || len(pattern) == 0 {
+		return
+	}
+	var period float32
+	for _, length := range pattern {
+		period += max(length, 0)
+	}
+	if period <= 0 {
+		return
+	}
+
+	// Find where in the pattern the path starts
+	index := 0
+	remaining := pattern[0]
+	phase := float32(math.Mod(float64(offset), float64(period)))
+	if phase < 0 {
+		phase += period
+	}
+	for phase > 0 {
+		if phase < remaining {
+			remaining -= phase
+			break
+		}
+		phase -= remaining
+		index = (index + 1) % len(pattern)
+		remaining = pattern[index]
+	}
+
+	segments := len(points) - 1
+	if closed {
+		segments++
+	}
+	for i := range segments {
+		start, end := points[i], points[(i+1)%len(points)]
+		length := float32(math.Hypot(float64(end.X-start.X), float64(end.Y-start.Y)))
+		if length == 0 {
+			continue
+		}
+		dx, dy := (end.X-start.X)/length, (end.Y-start.Y)/length
+
+		// Cut the segment into pattern pieces, even indices are drawn
+		for position := float32(0); position < length; {
+			step := min(remaining, length-position)
+			if index%2 == 0 && step > 0 {
+				x1, y1 := start.X+dx*position, start.Y+dy*position
+				DrawLine(renderer, x1, y1, x1+dx*step, y1+dy*step, thickness, color)
+			}
+			position += step
+			remaining -= step
+			if remaining <= 0 {
+				index = (index + 1) % len(pattern)
+				remaining = pattern[index]
+			}
+		}
+	}
+}
+
+// DrawDashedLine draws a straight dashed line
+func DrawDashedLine(renderer *sdl.Renderer, x1, y1, x2, y2, thickness float32, pattern []float32, offset float32, color sdl.Color) {
+	DrawDashedPath(renderer, []sdl.FPoint{{X: x1, Y: y1}, {X: x2, Y: y2}}, false, thickness, pattern, offset, color)
+}
+
+// DrawDashedRect draws a dashed rectangle outline centered on the rectangle edges
+func DrawDashedRect(renderer *sdl.Renderer, rect sdl.FRect, thickness float32, pattern []float32, offset float32, color sdl.Color) {
+	corners := rectCorners(rect)
+	DrawDashedPath(renderer, corners[:], true, thickness, pattern, offset, color)
+}
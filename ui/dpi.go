@@ -0,0 +1,53 @@
+// dpi.go
+package ui
+
+// HiDPI support: the renderer is scaled by the window pixel density so all
+// drawing and layout stays in logical (window) coordinates, while fonts are
+// rendered at the display scale so text stays sharp on Retina/4K screens.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// Physical pixels per logical unit, text textures are drawn at 1/pixelDensity size
+var pixelDensity float32 = 1
+
+// Helper function converting a texture's pixel size to logical size
+func textureLogicalSize(texture *sdl.Texture) (float32, float32) {
+	var w, h float32
+	sdl.GetTextureSize(texture, &w, &h)
+	return w / pixelDensity, h / pixelDensity
+}
+
+// Helper function returning the logical line height of a font
+func fontHeight(font *ttf.Font) float32 {
+	return float32(ttf.GetFontHeight(font)) / pixelDensity
+}
+
+// ApplyDisplayScale reads the window's pixel density and display scale and
+// updates the renderer and fonts. Returns true if anything changed, in which
+// case all text textures have been re-rendered.
+func ApplyDisplayScale(window *sdl.Window, renderer *sdl.Renderer, fonts *FontManager) bool {
+	density := sdl.GetWindowPixelDensity(window)
+	if density <= 0 {
+		density = 1
+	}
+	// Display scale includes the OS content scale (e.g. 150% on Windows)
+	scale := sdl.GetWindowDisplayScale(window)
+	if scale <= 0 {
+		scale = 1
+	}
+	if density == pixelDensity && scale == fonts.Scale {
+		return false
+	}
+
+	pixelDensity = density
+	sdl.SetRenderScale(renderer, density, density)
+	fonts.SetScale(scale)
+
+	// Text textures were rendered at the old size
+	textureTracker.RecreateText()
+	InvalidateRenderCaches()
+	return true
+}
@@ -0,0 +1,192 @@
+// dragdrop.go
+package ui
+
+// In-app drag and drop: a Draggable widget hands out a payload when the
+// mouse drags it, an image of it follows the mouse, and DropTarget widgets
+// under the mouse are highlighted when they accept the payload and receive
+// it on release. Escape cancels the drag.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// The mouse moves this far (logical units) with the button down before a drag starts
+const dragThreshold = 5
+
+// Draggable is implemented by widgets that can be dragged. DragPayload
+// returns what is dragged from x, y, or nil if nothing is.
+type Draggable interface {
+	Widget
+	DragPayload(x, y float32) any
+}
+
+// DragImager is implemented by draggables drawing their own drag image with
+// its top left corner at x, y (others are shown as a translucent box)
+type DragImager interface {
+	RenderDragImage(renderer *sdl.Renderer, x, y float32)
+}
+
+// DropTarget is implemented by widgets things can be dropped on
+type DropTarget interface {
+	Widget
+	AcceptsDrop(payload any) bool
+	Drop(payload any, x, y float32)
+}
+
+// DragDropManager runs drags between the widgets below Roots. Give it events
+// before the widgets and render it last.
+type DragDropManager struct {
+	Roots       []Widget
+	AcceptColor sdl.Color // Highlight of the target under the mouse
+
+	source       Draggable // Pressed on, drag starts after dragThreshold
+	payload      any
+	dragging     bool
+	target       DropTarget
+	startX       float32
+	startY       float32
+	mouseX       float32
+	mouseY       float32
+	sourceBounds sdl.FRect
+}
+
+func NewDragDropManager(roots ...Widget) *DragDropManager {
+	return &DragDropManager{Roots: roots, AcceptColor: sdl.Color{R: 90, G: 160, B: 255, A: 255}}
+}
+
+// Dragging reports whether a drag is in progress, and its payload
+func (d *DragDropManager) Dragging() (payload any, ok bool) {
+	return d.payload, d.dragging
+}
+
+// Cancel ends the drag without dropping
+func (d *DragDropManager) Cancel() {
+	d.source, d.payload, d.target, d.dragging = nil, nil, nil, false
+}
+
+// Update starts, follows and ends drags. Motion during a drag is used up
+// (the widgets below don't see it), returns true for it.
+func (d *DragDropManager) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventMouseButtonDown:
+		if sdl.MouseButtonFlags(event.Button().Button) != sdl.ButtonLeft {
+			return false
+		}
+		d.Cancel()
+		if source, ok := widgetAtMatching(d.Roots, sdl.FPoint{X: mx, Y: my}, isDraggable).(Draggable); ok {
+			d.source, d.startX, d.startY = source, mx, my
+		}
+	case sdl.EventMouseMotion:
+		if d.source == nil {
+			return false
+		}
+		d.mouseX, d.mouseY = mx, my
+		if !d.dragging {
+			dx, dy := mx-d.startX, my-d.startY
+			if dx*dx+dy*dy < dragThreshold*dragThreshold {
+				return false
+			}
+			if d.payload = d.source.DragPayload(d.startX, d.startY); d.payload == nil {
+				d.source = nil
+				return false
+			}
+			d.dragging = true
+			d.sourceBounds = d.source.GetBounds()
+		}
+		d.target, _ = widgetAtMatching(d.Roots, sdl.FPoint{X: mx, Y: my}, d.accepts).(DropTarget)
+		return true
+	case sdl.EventMouseButtonUp:
+		// The release still goes to the widgets, e.g. to end a pressed state
+		if d.dragging && d.target != nil {
+			d.target.Drop(d.payload, mx, my)
+		}
+		d.Cancel()
+	case sdl.EventKeyDown:
+		if d.dragging && event.Key().Scancode == sdl.ScancodeEscape {
+			d.Cancel()
+			return true
+		}
+	}
+	return false
+}
+
+// Render highlights the accepting target and draws the drag image at the mouse
+func (d *DragDropManager) Render(renderer *sdl.Renderer) {
+	if !d.dragging {
+		return
+	}
+	if d.target != nil {
+		bounds := d.target.GetBounds()
+		highlight := sdl.FRect{X: bounds.X - 2, Y: bounds.Y - 2, W: bounds.W + 4, H: bounds.H + 4}
+		DrawRoundedRect(renderer, highlight, 6, 2, d.AcceptColor)
+	}
+
+	// The image keeps the offset the mouse had when it grabbed the source
+	x := d.sourceBounds.X + d.mouseX - d.startX
+	y := d.sourceBounds.Y + d.mouseY - d.startY
+	defer PushOpacity(0.7)()
+	if imager, ok := d.source.(DragImager); ok {
+		imager.RenderDragImage(renderer, x, y)
+		return
+	}
+	ghost := sdl.FRect{X: x, Y: y, W: d.sourceBounds.W, H: d.sourceBounds.H}
+	FillRoundedRect(renderer, ghost, 6, sdl.Color{R: 200, G: 200, B: 200, A: 120})
+}
+
+func (d *DragDropManager) GetBounds() sdl.FRect {
+	return sdl.FRect{}
+}
+
+// Helper function returning the innermost widget containing point that matches, or nil
+func widgetAtMatching(widgets []Widget, point sdl.FPoint, matches func(Widget) bool) Widget {
+	for n := len(widgets) - 1; n >= 0; n-- { // Last drawn is on top
+		bounds := widgets[n].GetBounds()
+		if !sdl.PointInRectFloat(point, bounds) {
+			continue
+		}
+		if inner := widgetAtMatching(widgetChildren(widgets[n]), point, matches); inner != nil {
+			return inner
+		}
+		if matches(widgets[n]) {
+			return widgets[n]
+		}
+		return nil
+	}
+	return nil
+}
+
+// Helper function checking whether widget can be dragged
+func isDraggable(widget Widget) bool {
+	_, ok := widget.(Draggable)
+	return ok
+}
+
+// Helper function checking whether widget takes the dragged payload
+func (d *DragDropManager) accepts(widget Widget) bool {
+	target, ok := widget.(DropTarget)
+	return ok && target.AcceptsDrop(d.payload)
+}
+
+// Labels with DragData can be dragged, layouts with OnDrop accept drops
+
+func (l *Label) DragPayload(x, y float32) any {
+	return l.DragData
+}
+
+func (l *Label) RenderDragImage(renderer *sdl.Renderer, x, y float32) {
+	if l.Texture != nil {
+		rect := sdl.FRect{X: x, Y: y, W: l.Bounds.W, H: l.Bounds.H}
+		drawTexture(renderer, l.Texture, nil, &rect)
+	}
+}
+
+func (layout *Layout) AcceptsDrop(payload any) bool {
+	if layout.OnDrop == nil {
+		return false
+	}
+	return layout.AcceptDrop == nil || layout.AcceptDrop(payload)
+}
+
+func (layout *Layout) Drop(payload any, x, y float32) {
+	layout.OnDrop(payload, layout.IndexAt(x, y))
+}
@@ -0,0 +1,234 @@
+// draw.go
+package ui
+
+// Shape drawing helpers built on RenderGeometry, for shapes SDL has no
+// built-in call for (rounded rectangles, anti-aliased circles, ellipses and
+// thick lines). Anti-aliasing fades the shape edges out over one physical pixel.
+// They blend with the mode set by PushBlendMode, e.g. additive for glows.
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Helper function converting a color to the float color used by vertices (with the inherited opacity)
+func toFColor(color sdl.Color) sdl.FColor {
+	return sdl.FColor{R: float32(color.R) / 255, G: float32(color.G) / 255, B: float32(color.B) / 255, A: float32(color.A) / 255 * inheritedOpacity}
+}
+
+// Helper function returning how many segments make a smooth corner of radius
+func cornerSegments(radius float32) int {
+	return max(1, int(math.Ceil(float64(radius*pixelDensity)/2))) // In physical pixels
+}
+
+// Helper function returning the outline of a rounded rectangle, clockwise from the top-left corner
+func roundedRectOutline(rect sdl.FRect, radius float32, segments int) []sdl.FPoint {
+	radius = max(0, min(radius, rect.W/2, rect.H/2))
+
+	corners := [4]struct{ cx, cy, start float32 }{
+		{rect.X + radius, rect.Y + radius, math.Pi},                // Top-left
+		{rect.X + rect.W - radius, rect.Y + radius, 1.5 * math.Pi}, // Top-right
+		{rect.X + rect.W - radius, rect.Y + rect.H - radius, 0},    // Bottom-right
+		{rect.X + radius, rect.Y + rect.H - radius, 0.5 * math.Pi}, // Bottom-left
+	}
+	points := make([]sdl.FPoint, 0, 4*(segments+1))
+	for _, corner := range corners {
+		for i := 0; i <= segments; i++ {
+			angle := float64(corner.start) + float64(i)/float64(segments)*math.Pi/2
+			points = append(points, sdl.FPoint{
+				X: corner.cx + radius*float32(math.Cos(angle)),
+				Y: corner.cy + radius*float32(math.Sin(angle)),
+			})
+		}
+	}
+	return points
+}
+
+// FillRect fills a rectangle with the inherited opacity and the current blend mode
+func FillRect(renderer *sdl.Renderer, rect sdl.FRect, color sdl.Color) {
+	if batching > 0 {
+		frameBatch.FillRect(rect, color)
+		return
+	}
+	SetDrawColor(renderer, color.R, color.G, color.B, color.A)
+	sdl.RenderFillRect(renderer, &rect)
+}
+
+// FillRoundedRect fills a rectangle with corners rounded by radius
+func FillRoundedRect(renderer *sdl.Renderer, rect sdl.FRect, radius float32, color sdl.Color) {
+	if rect.W <= 0 || rect.H <= 0 {
+		return
+	}
+	outline := roundedRectOutline(rect, radius, cornerSegments(radius))
+	fcolor := toFColor(color)
+
+	// Triangle fan around the center
+	vertices := make([]sdl.Vertex, 0, len(outline)+1)
+	vertices = append(vertices, sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W/2, Y: rect.Y + rect.H/2}, Color: fcolor})
+	for _, point := range outline {
+		vertices = append(vertices, sdl.Vertex{Position: point, Color: fcolor})
+	}
+	indices := make([]int32, 0, len(outline)*3)
+	for i := range outline {
+		next := (i+1)%len(outline) + 1
+		indices = append(indices, 0, int32(i+1), int32(next))
+	}
+	renderBlendedGeometry(renderer, vertices, indices)
+}
+
+// DrawRoundedRect draws the border of a rounded rectangle, thickness grows inwards
+func DrawRoundedRect(renderer *sdl.Renderer, rect sdl.FRect, radius, thickness float32, color sdl.Color) {
+	if rect.W <= 0 || rect.H <= 0 || thickness <= 0 {
+		return
+	}
+	thickness = min(thickness, rect.W/2, rect.H/2)
+	segments := cornerSegments(radius) // Same for both outlines so their points pair up
+	outer := roundedRectOutline(rect, radius, segments)
+	inner := roundedRectOutline(sdl.FRect{X: rect.X + thickness, Y: rect.Y + thickness, W: rect.W - 2*thickness, H: rect.H - 2*thickness}, max(0, radius-thickness), segments)
+	fcolor := toFColor(color)
+
+	// Join the outlines with a strip of quads
+	vertices := make([]sdl.Vertex, 0, len(outer)*2)
+	for i := range outer {
+		vertices = append(vertices,
+			sdl.Vertex{Position: outer[i], Color: fcolor},
+			sdl.Vertex{Position: inner[i], Color: fcolor})
+	}
+	indices := make([]int32, 0, len(outer)*6)
+	for i := range outer {
+		a, b := int32(i*2), int32(i*2+1)
+		next := int32((i + 1) % len(outer) * 2)
+		indices = append(indices, a, b, next, b, next+1, next)
+	}
+	renderBlendedGeometry(renderer, vertices, indices)
+}
+
+// Helper function returning the points of an ellipse, clockwise from the right
+func ellipsePoints(cx, cy, rx, ry float32, segments int) []sdl.FPoint {
+	points := make([]sdl.FPoint, segments)
+	for i := range points {
+		angle := float64(i) / float64(segments) * 2 * math.Pi
+		points[i] = sdl.FPoint{X: cx + rx*float32(math.Cos(angle)), Y: cy + ry*float32(math.Sin(angle))}
+	}
+	return points
+}
+
+// Helper function appending the vertices of a closed ring of points in one color
+func appendRing(vertices []sdl.Vertex, points []sdl.FPoint, color sdl.FColor) []sdl.Vertex {
+	for _, point := range points {
+		vertices = append(vertices, sdl.Vertex{Position: point, Color: color})
+	}
+	return vertices
+}
+
+// Helper function appending the triangles joining two closed rings of n vertices starting at a and b
+func appendRingStrip(indices []int32, a, b int32, n int) []int32 {
+	for i := range int32(n) {
+		next := (i + 1) % int32(n)
+		indices = append(indices, a+i, b+i, a+next, b+i, b+next, a+next)
+	}
+	return indices
+}
+
+// Helper function drawing untextured geometry with the current blend mode
+// (alpha blending by default, needed for the faded edges)
+func renderBlendedGeometry(renderer *sdl.Renderer, vertices []sdl.Vertex, indices []int32) {
+	if batching > 0 {
+		frameBatch.AddGeometry(nil, vertices, indices)
+		return
+	}
+	sdl.SetRenderDrawBlendMode(renderer, drawBlendMode)
+	sdl.RenderGeometry(renderer, nil, vertices, indices)
+}
+
+// FillEllipse fills an anti-aliased ellipse
+func FillEllipse(renderer *sdl.Renderer, cx, cy, rx, ry float32, color sdl.Color) {
+	if rx <= 0 || ry <= 0 {
+		return
+	}
+	feather := 0.5 / pixelDensity // Half a physical pixel on each side of the edge
+	segments := 4 * cornerSegments(max(rx, ry))
+	solid := toFColor(color)
+	clear := solid
+	clear.A = 0
+
+	// Solid fan around the center, then a ring fading out across the edge
+	inner := ellipsePoints(cx, cy, max(0, rx-feather), max(0, ry-feather), segments)
+	outer := ellipsePoints(cx, cy, rx+feather, ry+feather, segments)
+	vertices := []sdl.Vertex{{Position: sdl.FPoint{X: cx, Y: cy}, Color: solid}}
+	vertices = appendRing(vertices, inner, solid)
+	vertices = appendRing(vertices, outer, clear)
+	indices := make([]int32, 0, segments*9)
+	for i := range int32(segments) {
+		indices = append(indices, 0, 1+i, 1+(i+1)%int32(segments))
+	}
+	indices = appendRingStrip(indices, 1, 1+int32(segments), segments)
+	renderBlendedGeometry(renderer, vertices, indices)
+}
+
+// FillCircle fills an anti-aliased circle
+func FillCircle(renderer *sdl.Renderer, cx, cy, radius float32, color sdl.Color) {
+	FillEllipse(renderer, cx, cy, radius, radius, color)
+}
+
+// DrawEllipse draws an anti-aliased ellipse outline centered on the radii
+func DrawEllipse(renderer *sdl.Renderer, cx, cy, rx, ry, thickness float32, color sdl.Color) {
+	if rx <= 0 || ry <= 0 || thickness <= 0 {
+		return
+	}
+	feather := 0.5 / pixelDensity
+	half := thickness / 2
+	segments := 4 * cornerSegments(max(rx, ry))
+	solid := toFColor(color)
+	clear := solid
+	clear.A = 0
+
+	// Four rings from the inside out: faded, solid, solid, faded
+	vertices := make([]sdl.Vertex, 0, segments*4)
+	vertices = appendRing(vertices, ellipsePoints(cx, cy, max(0, rx-half-feather), max(0, ry-half-feather), segments), clear)
+	vertices = appendRing(vertices, ellipsePoints(cx, cy, max(0, rx-half+feather), max(0, ry-half+feather), segments), solid)
+	vertices = appendRing(vertices, ellipsePoints(cx, cy, rx+half-feather, ry+half-feather, segments), solid)
+	vertices = appendRing(vertices, ellipsePoints(cx, cy, rx+half+feather, ry+half+feather, segments), clear)
+	indices := make([]int32, 0, segments*18)
+	for ring := range int32(3) {
+		indices = appendRingStrip(indices, ring*int32(segments), (ring+1)*int32(segments), segments)
+	}
+	renderBlendedGeometry(renderer, vertices, indices)
+}
+
+// DrawCircle draws an anti-aliased circle outline
+func DrawCircle(renderer *sdl.Renderer, cx, cy, radius, thickness float32, color sdl.Color) {
+	DrawEllipse(renderer, cx, cy, radius, radius, thickness, color)
+}
+
+// DrawLine draws an anti-aliased line of any thickness
+func DrawLine(renderer *sdl.Renderer, x1, y1, x2, y2, thickness float32, color sdl.Color) {
+	length := float32(math.Hypot(float64(x2-x1), float64(y2-y1)))
+	if length == 0 || thickness <= 0 {
+		return
+	}
+	feather := 0.5 / pixelDensity
+	solid := toFColor(color)
+	clear := solid
+	clear.A = 0
+
+	// Unit normal of the line, offsets are measured along it
+	nx, ny := -(y2-y1)/length, (x2-x1)/length
+	half := max(thickness/2, feather) // Hairlines still cover a full pixel
+	offsets := [4]float32{-half - feather, -half + feather, half - feather, half + feather}
+	colors := [4]sdl.FColor{clear, solid, solid, clear}
+
+	vertices := make([]sdl.Vertex, 0, 8)
+	for i, offset := range offsets {
+		vertices = append(vertices,
+			sdl.Vertex{Position: sdl.FPoint{X: x1 + nx*offset, Y: y1 + ny*offset}, Color: colors[i]},
+			sdl.Vertex{Position: sdl.FPoint{X: x2 + nx*offset, Y: y2 + ny*offset}, Color: colors[i]})
+	}
+	indices := make([]int32, 0, 18)
+	for i := range int32(3) {
+		a, b := i*2, (i+1)*2
+		indices = append(indices, a, a+1, b, a+1, b+1, b)
+	}
+	renderBlendedGeometry(renderer, vertices, indices)
+}
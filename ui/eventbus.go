@@ -0,0 +1,68 @@
+// eventbus.go
+package ui
+
+// Publish/subscribe between widgets and application state: widgets publish
+// semantic events ("counter.increment") and app code subscribes, instead of
+// every callback capturing the shared variables it changes.
+
+import (
+	"strings"
+)
+
+// EventBus delivers published events to the handlers subscribed to their topic
+type EventBus struct {
+	handlers map[string][]*subscription
+}
+
+// A registered handler (compared by pointer to unsubscribe)
+type subscription struct {
+	handler func(topic string, data any)
+}
+
+// Bus used by widgets that publish actions (see Button.Action)
+var Bus = NewEventBus()
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[string][]*subscription)}
+}
+
+// Subscribe calls handler for every event published on topic. A topic ending
+// in ".*" matches everything below it (e.g. "counter.*"), "*" matches all.
+// Returns a function that removes the subscription.
+func (b *EventBus) Subscribe(topic string, handler func(topic string, data any)) func() {
+	sub := &subscription{handler: handler}
+	b.handlers[topic] = append(b.handlers[topic], sub)
+	return func() {
+		subs := b.handlers[topic]
+		for i, s := range subs {
+			if s == sub {
+				b.handlers[topic] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish calls the handlers subscribed to topic right away, in subscription order
+func (b *EventBus) Publish(topic string, data any) {
+	for _, pattern := range topicPatterns(topic) {
+		// Copy so handlers may subscribe or unsubscribe while being called
+		subs := append([]*subscription(nil), b.handlers[pattern]...)
+		for _, sub := range subs {
+			sub.handler(topic, data)
+		}
+	}
+}
+
+// Helper function listing the subscription topics matching a published topic,
+// e.g. "a.b" is matched by "a.b", "a.*" and "*"
+func topicPatterns(topic string) []string {
+	patterns := []string{topic}
+	for i := strings.LastIndexByte(topic, '.'); i >= 0; i = strings.LastIndexByte(topic[:i], '.') {
+		patterns = append(patterns, topic[:i]+".*")
+	}
+	if topic != "*" {
+		patterns = append(patterns, "*")
+	}
+	return patterns
+}
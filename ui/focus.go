@@ -0,0 +1,322 @@
+// focus.go
+package ui
+
+// Keyboard focus: one widget at a time receives the keys, Tab and Shift+Tab
+// move focus through the focusable widgets in tree order, and a ring shows
+// where focus is after keyboard navigation. Gamepads move focus to the
+// nearest widget in the direction of the d-pad (or stick) and A activates it.
+// Alt with a mnemonic letter activates its widget (see mnemonic.go).
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Stick deflections (0 to 1) starting a gamepad navigation step, and below which the stick is back in the center
+const (
+	stickNavigatePress   = 0.6
+	stickNavigateRelease = 0.3
+)
+
+// Focusable is implemented by widgets that can take keyboard focus
+type Focusable interface {
+	Widget
+	Focus()
+	Blur()
+	HasFocus() bool // False once the widget gave up focus by itself (e.g. Escape in a TextInput)
+}
+
+// TextEditor is a focusable widget editing text. While one has focus the
+// FocusManager keeps SDL text input on, so typed characters arrive as UTF-8
+// in EventTextInput (with dead keys, compose sequences and IMEs applied)
+// instead of having to be rebuilt from key codes.
+type TextEditor interface {
+	Focusable
+	EditsText() bool // False while text input should stay off (e.g. read-only)
+}
+
+// Activatable is implemented by focusable widgets that do something when
+// the gamepad's A button is pressed on them (e.g. a button clicks)
+type Activatable interface {
+	Activate()
+}
+
+// FocusManager tracks the focused widget below Roots. Give it events before
+// the widgets: it handles Tab, routes key and text events to the focused
+// widget and moves focus to focusable widgets that are clicked.
+type FocusManager struct {
+	Roots           []Widget    // Searched depth first for Focusable widgets, in Tab order
+	Window          *sdl.Window // Text input is started and stopped on it (nil leaves that to the widgets)
+	RingColor       sdl.Color
+	StickNavigation bool // The left stick moves focus like the d-pad (games using the stick turn it off)
+	focused         Focusable
+	showRing        bool // Focus moved with the keyboard (clicks don't show the ring)
+	stickX          int  // Direction the stick last navigated in, until it returns to the center
+	stickY          int
+}
+
+func NewFocusManager(roots ...Widget) *FocusManager {
+	return &FocusManager{Roots: roots, RingColor: sdl.Color{R: 90, G: 160, B: 255, A: 255}, StickNavigation: true}
+}
+
+// Focused returns the widget with keyboard focus, or nil
+func (f *FocusManager) Focused() Focusable {
+	if f.focused != nil && !f.focused.HasFocus() {
+		f.focused = nil
+	}
+	return f.focused
+}
+
+// SetFocus moves focus to widget (nil clears it), the previous widget is blurred
+func (f *FocusManager) SetFocus(widget Focusable) {
+	if current := f.Focused(); current != nil && current != widget {
+		current.Blur()
+	}
+	f.focused = widget
+	f.showRing = false
+	if widget != nil {
+		widget.Focus()
+	}
+	f.updateTextInput()
+}
+
+// Helper function turning SDL text input on while a TextEditor has focus
+func (f *FocusManager) updateTextInput() {
+	if f.Window == nil {
+		return
+	}
+	editor, ok := f.focused.(TextEditor)
+	wantsText := ok && editor.EditsText()
+	if active := sdl.TextInputActive(f.Window); wantsText && !active {
+		sdl.StartTextInput(f.Window)
+	} else if !wantsText && active {
+		sdl.StopTextInput(f.Window)
+	}
+}
+
+// Next focuses the next focusable widget (wrapping around), Previous the one before
+func (f *FocusManager) Next() {
+	f.move(1)
+}
+
+func (f *FocusManager) Previous() {
+	f.move(-1)
+}
+
+// Helper function moving focus offset widgets along the Tab order
+func (f *FocusManager) move(offset int) {
+	widgets := f.focusables()
+	if len(widgets) == 0 {
+		return
+	}
+	index := -1
+	current := f.Focused()
+	for n, widget := range widgets {
+		if widget == current {
+			index = n
+		}
+	}
+	if index < 0 && offset < 0 {
+		index = 0 // Shift+Tab without focus starts at the last widget
+	}
+	index = (index + offset + len(widgets)) % len(widgets)
+	f.SetFocus(widgets[index])
+	f.showRing = true
+}
+
+// Navigate moves focus to the nearest focusable widget in direction dx, dy
+// (e.g. 0, -1 for up). Without focus the first widget is focused. Returns
+// false when there is no widget that way.
+func (f *FocusManager) Navigate(dx, dy float32) bool {
+	widgets := f.focusables()
+	current := f.Focused()
+	if current == nil {
+		if len(widgets) == 0 {
+			return false
+		}
+		f.SetFocus(widgets[0])
+		f.showRing = true
+		return true
+	}
+
+	from := rectCenter(current.GetBounds())
+	var best Focusable
+	bestScore := float32(math.MaxFloat32)
+	for _, widget := range widgets {
+		if widget == current {
+			continue
+		}
+		to := rectCenter(widget.GetBounds())
+		offsetX, offsetY := to.X-from.X, to.Y-from.Y
+		along := offsetX*dx + offsetY*dy // Distance in the direction
+		if along <= 0 {
+			continue
+		}
+		across := offsetX*dy - offsetY*dx // Distance sideways, counts double
+		score := along + 2*max(across, -across)
+		if score < bestScore {
+			best, bestScore = widget, score
+		}
+	}
+	if best == nil {
+		return false
+	}
+	f.SetFocus(best)
+	f.showRing = true
+	return true
+}
+
+// Helper function returning the center of a rectangle
+func rectCenter(rect sdl.FRect) sdl.FPoint {
+	return sdl.FPoint{X: rect.X + rect.W/2, Y: rect.Y + rect.H/2}
+}
+
+// Helper function navigating with a stick axis, once per push away from the center
+func (f *FocusManager) stickNavigate(axis sdl.GamepadAxis, value float32) bool {
+	direction := &f.stickX
+	if axis == sdl.GamepadAxisLeftY {
+		direction = &f.stickY
+	}
+	if max(value, -value) < stickNavigateRelease {
+		*direction = 0
+		return false
+	}
+	if max(value, -value) < stickNavigatePress || *direction != 0 {
+		return false
+	}
+	*direction = 1
+	if value < 0 {
+		*direction = -1
+	}
+	if axis == sdl.GamepadAxisLeftY {
+		return f.Navigate(0, float32(*direction))
+	}
+	return f.Navigate(float32(*direction), 0)
+}
+
+// Helper function listing the focusable, enabled widgets depth first
+func (f *FocusManager) focusables() []Focusable {
+	var widgets []Focusable
+	var walk func(widget Widget)
+	walk = func(widget Widget) {
+		if focusable, ok := widget.(Focusable); ok {
+			if button, isButton := widget.(*Button); !isButton || !button.Disabled {
+				widgets = append(widgets, focusable)
+			}
+		}
+		for _, child := range widgetChildren(widget) {
+			walk(child)
+		}
+	}
+	for _, root := range f.Roots {
+		walk(root)
+	}
+	return widgets
+}
+
+// Update handles Tab and focus changes on clicks, returns true when the event
+// was used (by the manager or the focused widget)
+func (f *FocusManager) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventKeyDown:
+		key := event.Key()
+		if key.Scancode == sdl.ScancodeTab && key.Mod&(sdl.KeymodCtrl|sdl.KeymodAlt|sdl.KeymodGui) == 0 {
+			if len(f.focusables()) == 0 {
+				return false
+			}
+			if key.Mod&sdl.KeymodShift != 0 {
+				f.Previous()
+			} else {
+				f.Next()
+			}
+			return true
+		}
+		// Alt+letter clicks the widget with that mnemonic, even from a text input
+		if owner := mnemonicFor(f.Roots, key); owner != nil {
+			owner.Activate()
+			return true
+		}
+		if focused := f.Focused(); focused != nil {
+			return focused.Update(event, mx, my)
+		}
+	case sdl.EventKeyUp, sdl.EventTextEditing, sdl.EventTextInput:
+		// Committed text and IME compositions go to the focused editor only
+		if focused := f.Focused(); focused != nil {
+			return focused.Update(event, mx, my)
+		}
+	case sdl.EventGamepadButtonDown:
+		switch sdl.GamepadButton(event.GButton().Button) {
+		case sdl.GamepadButtonDpadUp:
+			return f.Navigate(0, -1)
+		case sdl.GamepadButtonDpadDown:
+			return f.Navigate(0, 1)
+		case sdl.GamepadButtonDpadLeft:
+			return f.Navigate(-1, 0)
+		case sdl.GamepadButtonDpadRight:
+			return f.Navigate(1, 0)
+		case sdl.GamepadButtonSouth: // A
+			if activatable, ok := f.Focused().(Activatable); ok {
+				activatable.Activate()
+				return true
+			}
+		case sdl.GamepadButtonEast: // B
+			if f.Focused() != nil {
+				f.SetFocus(nil)
+				return true
+			}
+		}
+	case sdl.EventGamepadAxisMotion:
+		axis := event.GAxis()
+		if f.StickNavigation && (sdl.GamepadAxis(axis.Axis) == sdl.GamepadAxisLeftX || sdl.GamepadAxis(axis.Axis) == sdl.GamepadAxisLeftY) {
+			return f.stickNavigate(sdl.GamepadAxis(axis.Axis), float32(axis.Value)/32767)
+		}
+	case sdl.EventMouseButtonDown:
+		// Clicked widgets take focus, clicks elsewhere clear it. The click
+		// itself still goes to the widgets.
+		point := sdl.FPoint{X: mx, Y: my}
+		widgets := f.focusables()
+		for n := len(widgets) - 1; n >= 0; n-- { // Last drawn is on top
+			bounds := widgets[n].GetBounds()
+			if sdl.PointInRectFloat(point, bounds) {
+				f.SetFocus(widgets[n])
+				return false
+			}
+		}
+		f.SetFocus(nil)
+	}
+	return false
+}
+
+// Render draws the focus ring around the focused widget, call it after the widgets
+func (f *FocusManager) Render(renderer *sdl.Renderer) {
+	focused := f.Focused()
+	if focused == nil || !f.showRing {
+		return
+	}
+	bounds := focused.GetBounds()
+	ring := sdl.FRect{X: bounds.X - 3, Y: bounds.Y - 3, W: bounds.W + 6, H: bounds.H + 6}
+	DrawRoundedRect(renderer, ring, 8, 2, f.RingColor)
+}
+
+func (f *FocusManager) GetBounds() sdl.FRect {
+	return sdl.FRect{}
+}
+
+// EventPosition returns the position of mouse button, motion, wheel and pen
+// events (0, 0 for other events), the mx and my widgets expect
+func EventPosition(event sdl.Event) (x, y float32) {
+	switch event.Type() {
+	case sdl.EventMouseButtonDown, sdl.EventMouseButtonUp:
+		return event.Button().X, event.Button().Y
+	case sdl.EventMouseMotion:
+		return event.Motion().X, event.Motion().Y
+	case sdl.EventMouseWheel:
+		return event.Wheel().MouseX, event.Wheel().MouseY
+	case sdl.EventPenMotion:
+		return event.PMotion().X, event.PMotion().Y
+	case sdl.EventPenDown, sdl.EventPenUp:
+		return event.PTouch().X, event.PTouch().Y
+	}
+	return 0, 0
+}
@@ -0,0 +1,268 @@
+// fonts.go
+package ui
+
+// Font management: fonts are registered by name and large font files
+// (CJK, emoji) can be loaded in the background while a fallback font
+// is used for rendering in the meantime.
+
+import (
+	"errors"
+	"os"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// FontHandle refers to a font that may still be loading
+type FontHandle struct {
+	Name     string
+	Path     string
+	Size     float32
+	font     *ttf.Font
+	fallback *ttf.Font
+	data     []byte // Font file contents, must outlive the font opened from memory
+	external bool   // Font is owned by the caller, not closed by the manager
+	onReady  []func(font *ttf.Font)
+}
+
+// Font returns the loaded font, or the fallback font while loading
+func (h *FontHandle) Font() *ttf.Font {
+	if h.font != nil {
+		return h.font
+	}
+	return h.fallback
+}
+
+// Ready reports whether the real font has finished loading
+func (h *FontHandle) Ready() bool {
+	return h.font != nil
+}
+
+// OnReady registers a callback run once the real font is available.
+// If the font is already loaded the callback runs immediately.
+func (h *FontHandle) OnReady(callback func(font *ttf.Font)) {
+	if h.font != nil {
+		callback(h.font)
+		return
+	}
+	h.onReady = append(h.onReady, callback)
+}
+
+// Dedicated faces (e.g. a Bold .ttf) registered for style variants of a base font
+var fontVariants = map[*ttf.Font]map[ttf.FontStyleFlags]*ttf.Font{}
+
+// Helper function to pick the face for a style. Bold/italic use a registered
+// variant face when available, any remaining flags are synthesized by SDL_ttf.
+func styledFace(font *ttf.Font, style ttf.FontStyleFlags) (*ttf.Font, ttf.FontStyleFlags) {
+	variants := fontVariants[font]
+	weight := style & (ttf.StyleBold | ttf.StyleItalic)
+	if weight == 0 || variants == nil {
+		return font, style
+	}
+	if face := variants[weight]; face != nil {
+		return face, style &^ weight
+	}
+	// Bold italic without its own face: start from the bold or italic face
+	for _, partial := range []ttf.FontStyleFlags{ttf.StyleBold, ttf.StyleItalic} {
+		if weight&partial != 0 && variants[partial] != nil {
+			return variants[partial], style &^ partial
+		}
+	}
+	return font, style
+}
+
+// Helper function to run fn with the face for style, restoring the face style afterwards
+func withFontStyle(font *ttf.Font, style ttf.FontStyleFlags, fn func(face *ttf.Font)) {
+	face, synthesized := styledFace(font, style)
+	previous := ttf.GetFontStyle(face)
+	if previous == synthesized {
+		fn(face)
+		return
+	}
+	ttf.SetFontStyle(face, synthesized)
+	fn(face)
+	ttf.SetFontStyle(face, previous)
+}
+
+// Result of a background font file read
+type fontLoadResult struct {
+	handle *FontHandle
+	data   []byte
+	err    error
+}
+
+// FontManager owns all fonts used by the application
+type FontManager struct {
+	Fallback *ttf.Font
+	Scale    float32 // Display scale, fonts are opened at Size * Scale
+	handles  map[string]*FontHandle
+	results  chan fontLoadResult
+	closed   chan struct{} // Closed by Destroy, background reads stop waiting for Poll
+	OnError  func(handle *FontHandle, err error)
+}
+
+func NewFontManager(fallback *ttf.Font) *FontManager {
+	return &FontManager{
+		Fallback: fallback,
+		handles:  make(map[string]*FontHandle),
+		results:  make(chan fontLoadResult, 16),
+		closed:   make(chan struct{}),
+		Scale:    1,
+	}
+}
+
+// Load opens a font synchronously and registers it under the given name
+func (fm *FontManager) Load(name, path string, size float32) *FontHandle {
+	font := ttf.OpenFont(path, size*fm.Scale)
+	if font == nil {
+		panic(sdl.GetError())
+	}
+	handle := &FontHandle{Name: name, Path: path, Size: size, font: font, fallback: fm.Fallback}
+	fm.add(handle)
+	return handle
+}
+
+// Register adds a font opened elsewhere (the manager won't close it)
+func (fm *FontManager) Register(name string, font *ttf.Font) *FontHandle {
+	handle := &FontHandle{Name: name, Size: ttf.GetFontSize(font) / fm.Scale, font: font, fallback: fm.Fallback, external: true}
+	fm.add(handle)
+	return handle
+}
+
+// LoadAsync reads a font file in the background and returns immediately.
+// The returned handle renders with the fallback font until Poll swaps in the real one.
+func (fm *FontManager) LoadAsync(name, path string, size float32) *FontHandle {
+	handle := &FontHandle{Name: name, Path: path, Size: size, fallback: fm.Fallback}
+	fm.add(handle)
+
+	// Only the file read happens off the main thread, SDL_ttf calls stay on it
+	results, closed := fm.results, fm.closed
+	go func() {
+		data, err := os.ReadFile(path)
+		select {
+		case results <- fontLoadResult{handle: handle, data: data, err: err}:
+		case <-closed: // Nobody polls anymore
+		}
+	}()
+
+	return handle
+}
+
+// Helper function registering a handle, closing the font of the handle it
+// replaces (which then renders with the fallback font)
+func (fm *FontManager) add(handle *FontHandle) {
+	if old := fm.handles[handle.Name]; old != nil && old != handle {
+		fm.close(old)
+	}
+	fm.handles[handle.Name] = handle
+}
+
+// Helper function closing the font of a handle unless the caller owns it
+func (fm *FontManager) close(handle *FontHandle) {
+	delete(fontVariants, handle.font)
+	if handle.font != nil && !handle.external {
+		ttf.CloseFont(handle.font)
+	}
+	handle.font = nil
+}
+
+// LoadVariant opens a dedicated face for a style of base (e.g. StyleBold with a Bold .ttf).
+// Styles without a dedicated face are synthesized by SDL_ttf instead.
+func (fm *FontManager) LoadVariant(base *FontHandle, style ttf.FontStyleFlags, path string) *FontHandle {
+	variant := fm.Load(base.Name+variantSuffix(style), path, base.Size)
+	register := func(font *ttf.Font) {
+		if fontVariants[font] == nil {
+			fontVariants[font] = make(map[ttf.FontStyleFlags]*ttf.Font)
+		}
+		fontVariants[font][style&(ttf.StyleBold|ttf.StyleItalic)] = variant.font
+	}
+	// Async base fonts get their variants once they are ready
+	base.OnReady(register)
+	return variant
+}
+
+// Helper function naming variant handles, e.g. "ui-bold-italic"
+func variantSuffix(style ttf.FontStyleFlags) string {
+	suffix := ""
+	if style&ttf.StyleBold != 0 {
+		suffix += "-bold"
+	}
+	if style&ttf.StyleItalic != 0 {
+		suffix += "-italic"
+	}
+	return suffix
+}
+
+// SetScale resizes every font for a new display scale (sizes stay logical)
+func (fm *FontManager) SetScale(scale float32) {
+	fm.Scale = scale
+	for _, handle := range fm.handles {
+		if handle.font != nil {
+			ttf.SetFontSize(handle.font, handle.Size*scale)
+		}
+	}
+}
+
+// Get returns the handle registered under name, or nil
+func (fm *FontManager) Get(name string) *FontHandle {
+	return fm.handles[name]
+}
+
+// Poll finishes at most one pending font load per call so that
+// opening a large font and re-rendering its text does not stall a frame.
+// Call it once per frame from the main loop, it returns true when a font was swapped in.
+func (fm *FontManager) Poll() bool {
+	select {
+	case result := <-fm.results:
+		handle := result.handle
+		if fm.handles[handle.Name] != handle {
+			return false // Handle was replaced or the manager destroyed while loading
+		}
+		if result.err != nil {
+			if fm.OnError != nil {
+				fm.OnError(handle, result.err)
+			}
+			return false
+		}
+
+		font := ttf.OpenFontIO(sdl.IOFromConstMem(result.data), true, handle.Size*fm.Scale)
+		if font == nil {
+			if fm.OnError != nil {
+				fm.OnError(handle, errors.New(sdl.GetError()))
+			}
+			return false
+		}
+		handle.font = font
+		handle.data = result.data
+
+		// Let dependent widgets re-render their text with the real font
+		callbacks := handle.onReady
+		handle.onReady = nil
+		for _, callback := range callbacks {
+			callback(font)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (fm *FontManager) Destroy() {
+	for name, handle := range fm.handles {
+		fm.close(handle)
+		delete(fm.handles, name)
+	}
+	select {
+	case <-fm.closed:
+	default:
+		close(fm.closed)
+	}
+	// Drop reads that finished but were never polled
+	for {
+		select {
+		case <-fm.results:
+		default:
+			return
+		}
+	}
+}
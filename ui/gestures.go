@@ -0,0 +1,310 @@
+// gestures.go
+package ui
+
+// Touch gestures: finger events are recognized as taps, long-presses and
+// swipes, two fingers pinch and pan, and holding the left mouse button still
+// long-presses too. SDL still turns the first finger into mouse events, so
+// widgets are clicked and dragged by touch like with the mouse; the
+// recognizer adds what the mouse events can't express. Long-presses reach
+// LongPressable widgets, swipes and two-finger pans scroll the Scrollable
+// widget under the fingers.
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Thresholds of the gestures (logical units and seconds)
+const (
+	tapMaxDistance   = 10  // A finger moving less than this taps
+	longPressTime    = 0.5 // Default GestureRecognizer.LongPressTime
+	longPressSlop    = 10  // Default GestureRecognizer.LongPressSlop
+	swipeMinDistance = 50
+	swipeMaxTime     = 0.3
+	swipeStep        = 40 // Swipe distance scrolling like one wheel step
+)
+
+// Mouse events SDL makes up from touches carry this mouse id
+const touchMouseID = sdl.MouseID(0xFFFFFFFF)
+
+// Kinds of gestures
+type GestureKind int
+
+const (
+	GestureTap GestureKind = iota
+	GestureLongPress
+	GestureSwipe
+	GesturePinch // Two fingers moved apart or together
+	GesturePan   // Two fingers moved together over something that doesn't scroll
+)
+
+// Gesture is a recognized touch gesture
+type Gesture struct {
+	Kind   GestureKind
+	X, Y   float32 // Where the finger went down (two fingers: their center), in logical units
+	DX, DY float32 // Swipes: movement from X, Y. Pans: movement since the last pan.
+	Scale  float32 // Pinches: distance between the fingers relative to the last pinch
+}
+
+// LongPressable is implemented by widgets reacting to a long-press at x, y,
+// returns true if the widget used it
+type LongPressable interface {
+	LongPress(x, y float32) bool
+}
+
+// GestureRecognizer follows the fingers on Window. Give it every event and
+// tick it (long-presses fire while the finger is still down).
+type GestureRecognizer struct {
+	Window        *sdl.Window
+	Roots         []Widget // Long-presses and swipes go to the widgets under the finger
+	OnGesture     func(gesture Gesture)
+	LongPressTime float32 // Seconds a finger (or the mouse button) is held for a long-press
+	LongPressSlop float32 // How far it may move meanwhile, in logical units
+	fingers       map[sdl.FingerID]*touchPoint
+	mouse         *touchPoint // Left mouse button held down
+
+	// Last center and distance of two fingers
+	pinchX, pinchY float32
+	pinchDistance  float32
+}
+
+// A finger that is down
+type touchPoint struct {
+	startX, startY float32
+	x, y           float32
+	down           uint64 // Event timestamp when it went down (sdl.GetTicksNS time)
+	moved          bool   // Went further than tapMaxDistance
+	multi          bool   // Other fingers were down too, not a one-finger gesture
+	slipped        bool   // Went further than LongPressSlop
+	longPressed    bool
+}
+
+// Helper function returning the seconds a point has been down at time now
+// (event timestamps and sdl.GetTicksNS share a clock, frame times don't add up
+// while the loop waits for events)
+func (point *touchPoint) held(now uint64) float32 {
+	if now <= point.down {
+		return 0
+	}
+	return float32(now-point.down) / 1e9
+}
+
+func NewGestureRecognizer(window *sdl.Window, roots ...Widget) *GestureRecognizer {
+	return &GestureRecognizer{
+		Window:        window,
+		Roots:         roots,
+		LongPressTime: longPressTime,
+		LongPressSlop: longPressSlop,
+		fingers:       make(map[sdl.FingerID]*touchPoint),
+	}
+}
+
+// Update follows finger events, it never uses up the event
+func (r *GestureRecognizer) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventFingerDown:
+		finger := event.TFinger()
+		x, y := r.position(finger)
+		point := &touchPoint{startX: x, startY: y, x: x, y: y, down: event.Common().Timestamp, multi: len(r.fingers) > 0}
+		for _, other := range r.fingers {
+			other.multi = true
+		}
+		r.fingers[finger.FingerID] = point
+		r.startPinch()
+	case sdl.EventFingerMotion:
+		finger := event.TFinger()
+		point, ok := r.fingers[finger.FingerID]
+		if !ok {
+			return false
+		}
+		point.x, point.y = r.position(finger)
+		r.track(point)
+		r.pinch()
+	case sdl.EventFingerUp:
+		finger := event.TFinger()
+		point, ok := r.fingers[finger.FingerID]
+		if !ok {
+			return false
+		}
+		delete(r.fingers, finger.FingerID)
+		point.x, point.y = r.position(finger)
+		// Held long enough even if no frame ran meanwhile
+		r.tickPoint(point, event.Common().Timestamp)
+		r.fingerUp(point, event.Common().Timestamp)
+		r.startPinch()
+	case sdl.EventFingerCanceled:
+		delete(r.fingers, event.TFinger().FingerID)
+		r.startPinch()
+	case sdl.EventMouseButtonDown:
+		// Touches were seen as fingers already
+		if button := event.Button(); button.Which != touchMouseID && sdl.MouseButtonFlags(button.Button) == sdl.ButtonLeft {
+			r.mouse = &touchPoint{startX: mx, startY: my, x: mx, y: my, down: event.Common().Timestamp}
+		}
+	case sdl.EventMouseMotion:
+		if r.mouse != nil {
+			r.mouse.x, r.mouse.y = mx, my
+			r.track(r.mouse)
+		}
+	case sdl.EventMouseButtonUp:
+		if sdl.MouseButtonFlags(event.Button().Button) == sdl.ButtonLeft && r.mouse != nil {
+			r.tickPoint(r.mouse, event.Common().Timestamp)
+			r.mouse = nil
+		}
+	}
+	return false
+}
+
+// Helper function noting when a point moved too far for a tap or a long-press
+func (r *GestureRecognizer) track(point *touchPoint) {
+	dx, dy := point.x-point.startX, point.y-point.startY
+	distance := dx*dx + dy*dy
+	if distance > tapMaxDistance*tapMaxDistance {
+		point.moved = true
+	}
+	if distance > r.LongPressSlop*r.LongPressSlop {
+		point.slipped = true
+	}
+}
+
+// Helper function recognizing the gesture of a finger lifted at time up
+func (r *GestureRecognizer) fingerUp(point *touchPoint, up uint64) {
+	if point.multi || point.longPressed {
+		return
+	}
+	dx, dy := point.x-point.startX, point.y-point.startY
+	if !point.moved {
+		r.emit(Gesture{Kind: GestureTap, X: point.startX, Y: point.startY})
+		return
+	}
+	if point.held(up) <= swipeMaxTime && dx*dx+dy*dy >= swipeMinDistance*swipeMinDistance {
+		// Content follows the finger: swiping down scrolls up like the wheel
+		scrollAt(r.Roots, sdl.FPoint{X: point.startX, Y: point.startY}, dx/swipeStep, dy/swipeStep)
+		r.emit(Gesture{Kind: GestureSwipe, X: point.startX, Y: point.startY, DX: dx, DY: dy})
+	}
+}
+
+// Helper function returning the center of two fingers and their distance,
+// ok is false unless exactly two fingers are down
+func (r *GestureRecognizer) twoFingers() (x, y, distance float32, ok bool) {
+	if len(r.fingers) != 2 {
+		return 0, 0, 0, false
+	}
+	var points []*touchPoint
+	for _, point := range r.fingers {
+		points = append(points, point)
+	}
+	a, b := points[0], points[1]
+	distance = float32(math.Hypot(float64(b.x-a.x), float64(b.y-a.y)))
+	return (a.x + b.x) / 2, (a.y + b.y) / 2, distance, true
+}
+
+// Helper function remembering where two fingers start pinching and panning
+func (r *GestureRecognizer) startPinch() {
+	if x, y, distance, ok := r.twoFingers(); ok {
+		r.pinchX, r.pinchY, r.pinchDistance = x, y, distance
+	}
+}
+
+// Helper function reporting how two fingers moved since the last motion
+func (r *GestureRecognizer) pinch() {
+	x, y, distance, ok := r.twoFingers()
+	if !ok {
+		return
+	}
+	if r.pinchDistance > 0 && distance > 0 && distance != r.pinchDistance {
+		r.emit(Gesture{Kind: GesturePinch, X: x, Y: y, Scale: distance / r.pinchDistance})
+	}
+	dx, dy := x-r.pinchX, y-r.pinchY
+	if dx != 0 || dy != 0 {
+		// Scrollable widgets under the fingers scroll, anything else gets a pan
+		if !scrollAt(r.Roots, sdl.FPoint{X: x, Y: y}, dx/swipeStep, dy/swipeStep) {
+			r.emit(Gesture{Kind: GesturePan, X: x, Y: y, DX: dx, DY: dy})
+		}
+	}
+	r.pinchX, r.pinchY, r.pinchDistance = x, y, distance
+}
+
+// Tick times long-presses, returns true while fingers (or the mouse button) are down
+func (r *GestureRecognizer) Tick(dt float32) bool {
+	now := sdl.GetTicksNS()
+	for _, point := range r.fingers {
+		r.tickPoint(point, now)
+	}
+	if r.mouse != nil {
+		r.tickPoint(r.mouse, now)
+	}
+	return len(r.fingers) > 0 || (r.mouse != nil && !r.mouse.longPressed && !r.mouse.slipped)
+}
+
+// Helper function long-pressing once a point was held long enough at time now
+// (sdl.GetTicksNS while ticking, the event timestamp when it's lifted)
+func (r *GestureRecognizer) tickPoint(point *touchPoint, now uint64) {
+	if point.slipped || point.multi || point.longPressed || point.held(now) < r.LongPressTime {
+		return
+	}
+	point.longPressed = true
+	longPressAt(r.Roots, sdl.FPoint{X: point.startX, Y: point.startY})
+	r.emit(Gesture{Kind: GestureLongPress, X: point.startX, Y: point.startY})
+}
+
+// Helper function reporting a gesture
+func (r *GestureRecognizer) emit(gesture Gesture) {
+	if r.OnGesture != nil {
+		r.OnGesture(gesture)
+	}
+}
+
+// Helper function converting the normalized finger position to logical units
+func (r *GestureRecognizer) position(finger sdl.TouchFingerEvent) (x, y float32) {
+	var w, h int32
+	sdl.GetWindowSize(r.Window, &w, &h)
+	return finger.X * float32(w), finger.Y * float32(h)
+}
+
+// Helper function long-pressing the innermost LongPressable widget containing point
+func longPressAt(widgets []Widget, point sdl.FPoint) bool {
+	for n := len(widgets) - 1; n >= 0; n-- { // Last drawn is on top
+		bounds := widgets[n].GetBounds()
+		if !sdl.PointInRectFloat(point, bounds) {
+			continue
+		}
+		if longPressAt(widgetChildren(widgets[n]), point) {
+			return true
+		}
+		pressable, ok := widgets[n].(LongPressable)
+		return ok && pressable.LongPress(point.X, point.Y)
+	}
+	return false
+}
+
+// Long-presses of the built-in widgets: OnLongPress (e.g. to open a context
+// menu), text is selected like on phones
+
+func (b *Button) LongPress(x, y float32) bool {
+	if b.OnLongPress == nil || b.Disabled {
+		return false
+	}
+	b.OnLongPress(x, y)
+	return true
+}
+
+func (t *TextInput) LongPress(x, y float32) bool {
+	t.Focus()
+	t.SelectAll()
+	return true
+}
+
+func (l *Label) LongPress(x, y float32) bool {
+	if l.OnLongPress != nil {
+		l.OnLongPress(x, y)
+		return true
+	}
+	if !l.Selectable || l.Markup {
+		return false
+	}
+	l.selectionAnchor = 0
+	l.selectionCursor = len(l.displayText)
+	l.MarkDirty()
+	return true
+}
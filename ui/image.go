@@ -0,0 +1,223 @@
+// image.go
+package ui
+
+// Image files as textures: BMP is loaded by SDL, PNG and JPEG are decoded in
+// Go, so no extra native library is needed. The Image widget draws a loaded
+// picture scaled into its bounds and can be copied and pasted.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Helper function loading a texture from an image file (BMP, PNG or JPEG).
+// Magenta pixels of BMP files are transparent, the other formats carry alpha.
+// Returns nil on failure (see sdl.GetError).
+func loadTexture(renderer *sdl.Renderer, path string) *sdl.Texture {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		sdl.SetError("%v", err)
+		return nil
+	}
+	return textureFromData(renderer, path, data)
+}
+
+// Helper function creating a texture from the contents of an image file, the
+// name's extension tells BMP apart. Returns nil on failure (see sdl.GetError).
+func textureFromData(renderer *sdl.Renderer, name string, data []byte) *sdl.Texture {
+	if strings.EqualFold(path.Ext(name), ".bmp") {
+		surface := sdl.LoadBMPIO(sdl.IOFromConstMem(data), true)
+		if surface == nil {
+			return nil
+		}
+		defer sdl.DestroySurface(surface)
+		sdl.SetSurfaceColorKey(surface, true, sdl.MapSurfaceRGB(surface, 255, 0, 255))
+		return sdl.CreateTextureFromSurface(renderer, surface)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		sdl.SetError("%s: %v", name, err)
+		return nil
+	}
+	return textureFromImage(renderer, decoded)
+}
+
+// Helper function uploading a decoded image into a new texture, returns nil on failure
+func textureFromImage(renderer *sdl.Renderer, img image.Image) *sdl.Texture {
+	// SDL blends straight (non-premultiplied) alpha
+	pixels := toNRGBA(img)
+	size := pixels.Rect.Size()
+	if size.X == 0 || size.Y == 0 {
+		sdl.SetError("empty image")
+		return nil
+	}
+	texture := sdl.CreateTexture(renderer, sdl.PixelFormatRGBA32, sdl.TextureAccessStatic, int32(size.X), int32(size.Y))
+	if texture == nil {
+		return nil
+	}
+	sdl.UpdateTexture(texture, nil, unsafe.Pointer(&pixels.Pix[0]), int32(pixels.Stride))
+	return texture
+}
+
+// SurfaceFromData decodes the contents of an image file (BMP, PNG or JPEG,
+// the name's extension tells BMP apart) into a new surface, the caller
+// destroys it. Returns nil on failure (see sdl.GetError).
+func SurfaceFromData(name string, data []byte) *sdl.Surface {
+	if strings.EqualFold(path.Ext(name), ".bmp") {
+		return sdl.LoadBMPIO(sdl.IOFromConstMem(data), true)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		sdl.SetError("%s: %v", name, err)
+		return nil
+	}
+	return surfaceFromNRGBA(toNRGBA(decoded))
+}
+
+// Helper function decoding the contents of an image file (BMP, PNG or JPEG,
+// the name's extension tells BMP apart)
+func decodeImage(name string, data []byte) (image.Image, error) {
+	if !strings.EqualFold(path.Ext(name), ".bmp") {
+		decoded, _, err := image.Decode(bytes.NewReader(data))
+		return decoded, err
+	}
+	surface := SurfaceFromData(name, data)
+	if surface == nil {
+		return nil, errors.New(sdl.GetError())
+	}
+	defer sdl.DestroySurface(surface)
+	decoded := nrgbaFromSurface(surface)
+	if decoded == nil {
+		return nil, errors.New(sdl.GetError())
+	}
+	return decoded, nil
+}
+
+// LoadTexture loads an image file (BMP, PNG or JPEG) into a texture, the caller owns it.
+// Returns nil on failure (see sdl.GetError).
+func LoadTexture(renderer *sdl.Renderer, path string) *sdl.Texture {
+	return loadTexture(renderer, path)
+}
+
+// Image widget showing a picture loaded from a file
+type Image struct {
+	Bounds   sdl.FRect
+	Texture  *sdl.Texture
+	Opacity  float32
+	path     string
+	pixels   *image.NRGBA // Set with SetImage (e.g. pasted), replaces the file
+	renderer *sdl.Renderer
+	Dirty
+}
+
+// NewImage loads an image file shown in bounds. A zero width or height is taken from the image size.
+func NewImage(renderer *sdl.Renderer, path string, bounds sdl.FRect) *Image {
+	img := &Image{Bounds: bounds, Opacity: 1, path: path, renderer: renderer}
+	if err := img.load(); err != nil {
+		panic(err)
+	}
+	if img.Bounds.W == 0 || img.Bounds.H == 0 {
+		var w, h float32
+		sdl.GetTextureSize(img.Texture, &w, &h)
+		img.Bounds.W, img.Bounds.H = w, h
+	}
+	textureTracker.Track(img, img.load)
+	return img
+}
+
+// Load the texture from the file or the set pixels (also used to recreate it after a device reset)
+func (img *Image) load() error {
+	var texture *sdl.Texture
+	if img.pixels != nil {
+		texture = textureFromImage(img.renderer, img.pixels)
+	} else {
+		texture = loadTexture(img.renderer, img.path)
+	}
+	if texture == nil {
+		return fmt.Errorf("%s: %s", img.path, sdl.GetError())
+	}
+	if img.Texture != nil {
+		sdl.DestroyTexture(img.Texture)
+	}
+	img.Texture = texture
+	img.MarkDirty()
+	return nil
+}
+
+// SetImage replaces the picture, shown scaled into the same bounds
+func (img *Image) SetImage(picture image.Image) {
+	img.pixels = cloneNRGBA(toNRGBA(picture)) // The caller may change picture later
+	if err := img.load(); err != nil {
+		slog.Warn("image not shown", "error", err)
+	}
+}
+
+// Picture returns the shown picture, decoding the file if it came from one
+func (img *Image) Picture() (image.Image, error) {
+	if img.pixels != nil {
+		return img.pixels, nil
+	}
+	data, err := os.ReadFile(img.path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeImage(img.path, data)
+}
+
+// Copy puts the picture on the clipboard, returns false on failure
+func (img *Image) Copy() bool {
+	picture, err := img.Picture()
+	if err != nil {
+		slog.Warn("image not copied", "error", err)
+		return false
+	}
+	return SetClipboardImage(picture)
+}
+
+// Paste shows the image on the clipboard, returns false if there is none
+func (img *Image) Paste() bool {
+	picture, ok := GetClipboardImage()
+	if ok {
+		img.SetImage(picture)
+	}
+	return ok
+}
+
+func (img *Image) Update(event sdl.Event, mx, my float32) bool {
+	return false
+}
+
+func (img *Image) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(img.Opacity)()
+	drawTexture(renderer, img.Texture, nil, &img.Bounds)
+}
+
+func (img *Image) GetBounds() sdl.FRect {
+	return img.Bounds
+}
+
+func (img *Image) SetPosition(x, y float32) {
+	img.Bounds.X, img.Bounds.Y = x, y
+}
+
+// Destroy frees the texture
+func (img *Image) Destroy() {
+	textureTracker.Untrack(img)
+	if img.Texture != nil {
+		sdl.DestroyTexture(img.Texture)
+		img.Texture = nil
+	}
+}
@@ -0,0 +1,247 @@
+// inspector.go
+package ui
+
+// Developer panel listing the live widget hierarchy. Toggle it with F12,
+// click a row (or Alt+click a widget on screen) to select a widget: its
+// bounds are highlighted and its text and state are shown below the tree.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// Inspector shows the widget trees below Roots. Give it events before the
+// widgets and render it last.
+type Inspector struct {
+	Visible  bool
+	Key      sdl.Scancode // Toggles the panel
+	Roots    []Widget
+	Selected Widget
+	Width    float32 // Panel width
+	font     *ttf.Font
+	rows     []inspectorRow // Rows of the last rendered frame, for clicks
+	panel    sdl.FRect
+}
+
+// A widget listed in the panel
+type inspectorRow struct {
+	widget Widget
+	depth  int
+	rect   sdl.FRect
+}
+
+func NewInspector(font *ttf.Font, roots ...Widget) *Inspector {
+	return &Inspector{Key: sdl.ScancodeF12, Roots: roots, Width: 360, font: font}
+}
+
+// Update toggles the panel and handles selection, returns true for events it used
+func (i *Inspector) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventKeyDown:
+		if event.Key().Scancode == i.Key {
+			i.Visible = !i.Visible
+			return true
+		}
+		if !i.Visible {
+			return false
+		}
+		switch event.Key().Scancode {
+		case sdl.ScancodeUp:
+			i.moveSelection(-1)
+			return true
+		case sdl.ScancodeDown:
+			i.moveSelection(1)
+			return true
+		}
+	case sdl.EventMouseButtonDown:
+		if !i.Visible {
+			return false
+		}
+		point := sdl.FPoint{X: mx, Y: my}
+		for _, row := range i.rows {
+			if sdl.PointInRectFloat(point, row.rect) {
+				i.Selected = row.widget
+				return true
+			}
+		}
+		if sdl.GetModState()&sdl.KeymodAlt != 0 {
+			i.Selected = widgetAt(i.Roots, point)
+			return true
+		}
+		return sdl.PointInRectFloat(point, i.panel) // Widgets behind the panel don't get the click
+	}
+	return false
+}
+
+// Helper function selecting the row offset rows away from the selected one
+func (i *Inspector) moveSelection(offset int) {
+	rows := i.collectRows()
+	if len(rows) == 0 {
+		return
+	}
+	index := -1
+	for n, row := range rows {
+		if row.widget == i.Selected {
+			index = n
+		}
+	}
+	index = max(0, min(index+offset, len(rows)-1))
+	i.Selected = rows[index].widget
+}
+
+// Helper function listing the widgets depth first
+func (i *Inspector) collectRows() []inspectorRow {
+	var rows []inspectorRow
+	var walk func(widget Widget, depth int)
+	walk = func(widget Widget, depth int) {
+		rows = append(rows, inspectorRow{widget: widget, depth: depth})
+		for _, child := range widgetChildren(widget) {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range i.Roots {
+		walk(root, 0)
+	}
+	return rows
+}
+
+func (i *Inspector) Render(renderer *sdl.Renderer) {
+	if !i.Visible {
+		return
+	}
+	// Highlight the selected widget where it is on screen
+	if i.Selected != nil {
+		bounds := i.Selected.GetBounds()
+		SetDrawColor(renderer, 255, 0, 255, 60)
+		sdl.RenderFillRect(renderer, &bounds)
+		SetDrawColor(renderer, 255, 0, 255, 255)
+		sdl.RenderRect(renderer, &bounds)
+	}
+
+	var outputW, outputH int32
+	sdl.GetCurrentRenderOutputSize(renderer, &outputW, &outputH)
+	i.panel = sdl.FRect{X: float32(outputW)/pixelDensity - i.Width, W: i.Width, H: float32(outputH) / pixelDensity}
+	panel := i.panel
+	SetDrawColor(renderer, 20, 20, 30, 220)
+	sdl.RenderFillRect(renderer, &panel)
+
+	lineHeight := LineSkip(i.font)
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	y := panel.Y + 8
+	// Draw calls the layouts needed for their widgets so far this frame
+	DrawText(renderer, i.font, fmt.Sprintf("Batched draw calls: %d", BatchDrawCalls()), panel.X+8, y, white)
+	y += lineHeight
+	i.rows = i.collectRows()
+	for n := range i.rows {
+		row := &i.rows[n]
+		row.rect = sdl.FRect{X: panel.X, Y: y, W: panel.W, H: lineHeight}
+		if row.widget == i.Selected {
+			SetDrawColor(renderer, 90, 60, 140, 255)
+			sdl.RenderFillRect(renderer, &row.rect)
+		}
+		DrawText(renderer, i.font, describeWidget(row.widget), panel.X+8+float32(row.depth)*16, y, white)
+		y += lineHeight
+	}
+
+	// Details of the selection
+	if i.Selected == nil {
+		return
+	}
+	y += lineHeight / 2
+	SetDrawColor(renderer, 255, 255, 255, 80)
+	sdl.RenderLine(renderer, panel.X+8, y, panel.X+panel.W-8, y)
+	y += lineHeight / 2
+	for _, line := range widgetDetails(i.Selected) {
+		DrawText(renderer, i.font, line, panel.X+8, y, white)
+		y += lineHeight
+	}
+}
+
+func (i *Inspector) GetBounds() sdl.FRect {
+	return sdl.FRect{}
+}
+
+// Helper function returning the widgets contained in a widget
+func widgetChildren(widget Widget) []Widget {
+	switch w := widget.(type) {
+	case *Layout:
+		return w.Widgets
+	case *CachedWidget:
+		return []Widget{w.Widget}
+	case *UIWatcher:
+		return []Widget{w.Tree.Root}
+	case *ScrollArea:
+		return []Widget{w.Content}
+	case *TitleBar:
+		return []Widget{w.Title, w.Minimize, w.Maximize, w.Close}
+	}
+	return nil
+}
+
+// Helper function returning the innermost widget containing point, or nil
+func widgetAt(widgets []Widget, point sdl.FPoint) Widget {
+	for n := len(widgets) - 1; n >= 0; n-- { // Last drawn is on top
+		bounds := widgets[n].GetBounds()
+		if !sdl.PointInRectFloat(point, bounds) {
+			continue
+		}
+		if inner := widgetAt(widgetChildren(widgets[n]), point); inner != nil {
+			return inner
+		}
+		return widgets[n]
+	}
+	return nil
+}
+
+// Helper function returning the one-line summary of a widget shown in the tree
+func describeWidget(widget Widget) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", widget), "*ui.")
+	switch w := widget.(type) {
+	case *Button:
+		return fmt.Sprintf("%s %q", name, w.Text)
+	case *Label:
+		return fmt.Sprintf("%s %q", name, w.Text)
+	case *TextInput:
+		return fmt.Sprintf("%s %q", name, w.Text)
+	case *Link:
+		return fmt.Sprintf("%s %q", name, w.Label.Text)
+	}
+	return name
+}
+
+// Helper function returning the lines describing the selected widget
+func widgetDetails(widget Widget) []string {
+	bounds := widget.GetBounds()
+	lines := []string{
+		describeWidget(widget),
+		fmt.Sprintf("X %.1f  Y %.1f  W %.1f  H %.1f", bounds.X, bounds.Y, bounds.W, bounds.H),
+	}
+	switch w := widget.(type) {
+	case *Button:
+		lines = append(lines,
+			fmt.Sprintf("Pressed %t  Hovered %t  Disabled %t", w.IsPressed, w.Hovered, w.Disabled),
+			fmt.Sprintf("Opacity %.2f  Action %q", w.Opacity, w.Action))
+	case *Label:
+		lines = append(lines,
+			fmt.Sprintf("Shown %q", w.displayText),
+			fmt.Sprintf("Markup %t  Opacity %.2f", w.Markup, w.Opacity))
+	case *TextInput:
+		lines = append(lines,
+			fmt.Sprintf("Focused %t  Cursor %d  Anchor %d", w.Focused, w.Cursor, w.Anchor),
+			fmt.Sprintf("Opacity %.2f", w.Opacity))
+	case *Layout:
+		lines = append(lines,
+			fmt.Sprintf("Widgets %d  Vertical %t  Spacing %.1f", len(w.Widgets), w.Vertical, w.Spacing),
+			fmt.Sprintf("Cached %t  Opacity %.2f", w.CacheRender, w.Opacity))
+	case *ScrollArea:
+		lines = append(lines, fmt.Sprintf("Offset %.1f of %.1f", w.Offset, w.MaxOffset()))
+	case *Link:
+		lines = append(lines,
+			fmt.Sprintf("URL %q", w.URL),
+			fmt.Sprintf("Hovered %t  Focused %t", w.Hovered, w.Focused))
+	}
+	return lines
+}
@@ -0,0 +1,132 @@
+// link.go
+package ui
+
+// Links: underlined text opening a web page (or a mailto: address) in the
+// system browser when clicked, e.g. in About dialogs.
+
+import (
+	"log/slog"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// OpenURL opens url with the application the system uses for it (a browser
+// for web pages), returns false if that failed
+func OpenURL(url string) bool {
+	if !sdl.OpenURL(url) {
+		slog.Warn("URL not opened", "url", url, "error", sdl.GetError())
+		return false
+	}
+	return true
+}
+
+// Link is text opening URL when clicked, or activated from the keyboard or a gamepad
+type Link struct {
+	Label   *Label
+	URL     string
+	Color   sdl.Color // Text color (HoverColor under the mouse)
+	Hovered bool
+	Focused bool
+	OnOpen  func(url string) // Replaces opening URL in the browser when set
+	pressed bool
+	Dirty
+}
+
+func NewLink(x, y float32, text, url string, font *ttf.Font, renderer *sdl.Renderer) *Link {
+	label := NewLabel(x, y, text, font, renderer)
+	label.SetStyle(ttf.StyleUnderline)
+	return &Link{Label: label, URL: url, Color: sdl.Color{R: 110, G: 170, B: 255, A: 255}}
+}
+
+// Activate opens the link
+func (l *Link) Activate() {
+	if l.OnOpen != nil {
+		l.OnOpen(l.URL)
+		return
+	}
+	OpenURL(l.URL)
+}
+
+func (l *Link) Focus() {
+	l.Focused = true
+	l.MarkDirty()
+}
+
+func (l *Link) Blur() {
+	l.Focused = false
+	l.MarkDirty()
+}
+
+func (l *Link) HasFocus() bool {
+	return l.Focused
+}
+
+func (l *Link) Update(event sdl.Event, mx, my float32) bool {
+	inside := sdl.PointInRectFloat(sdl.FPoint{X: mx, Y: my}, l.Label.Bounds)
+	switch event.Type() {
+	case sdl.EventMouseMotion, sdl.EventWindowMouseLeave:
+		hovered := inside && event.Type() == sdl.EventMouseMotion
+		if hovered != l.Hovered {
+			l.Hovered = hovered
+			l.MarkDirty()
+			return true
+		}
+	case sdl.EventMouseButtonDown:
+		if inside && sdl.MouseButtonFlags(event.Button().Button) == sdl.ButtonLeft {
+			l.pressed = true
+			return true
+		}
+	case sdl.EventMouseButtonUp:
+		if l.pressed && sdl.MouseButtonFlags(event.Button().Button) == sdl.ButtonLeft {
+			l.pressed = false
+			if inside {
+				l.Activate()
+			}
+			return true
+		}
+	case sdl.EventKeyDown:
+		if l.Focused && (event.Key().Scancode == sdl.ScancodeReturn || event.Key().Scancode == sdl.ScancodeKpEnter) {
+			l.Activate()
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Link) Render(renderer *sdl.Renderer) {
+	color := l.Color
+	if l.Hovered {
+		// Lighter under the mouse
+		color.R, color.G, color.B = color.R/2+128, color.G/2+128, color.B/2+128
+	}
+	if l.Label.Texture != nil {
+		sdl.SetTextureColorMod(l.Label.Texture, color.R, color.G, color.B)
+		defer sdl.SetTextureColorMod(l.Label.Texture, 255, 255, 255)
+	}
+	l.Label.Render(renderer)
+}
+
+func (l *Link) GetBounds() sdl.FRect {
+	return l.Label.Bounds
+}
+
+func (l *Link) SetPosition(x, y float32) {
+	l.Label.Bounds.X, l.Label.Bounds.Y = x, y
+}
+
+func (l *Link) MouseCursor(mx, my float32) sdl.SystemCursor {
+	return sdl.SystemCursorPointer
+}
+
+func (l *Link) takeDirty() bool {
+	dirty := l.Dirty.takeDirty()
+	if l.Label.takeDirty() {
+		dirty = true
+	}
+	return dirty
+}
+
+func (l *Link) Destroy() {
+	l.Label.Destroy()
+}
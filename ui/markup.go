@@ -0,0 +1,281 @@
+// markup.go
+package ui
+
+// Inline rich-text markup, e.g. "[b]bold[/b] [color=#ff0]warn[/color]".
+// Supported tags: [b] [i] [u] [s] and [color=#rgb], [color=#rrggbb], [color=#rrggbbaa].
+// Tags nest, unknown tags are kept as literal text and "[[" produces a literal "[".
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// TextSpan is a run of text sharing one style
+type TextSpan struct {
+	Text     string
+	Style    ttf.FontStyleFlags
+	Color    sdl.Color
+	HasColor bool // Use Color instead of the default text color
+}
+
+// Style state while parsing
+type markupState struct {
+	style    ttf.FontStyleFlags
+	color    sdl.Color
+	hasColor bool
+	tag      string
+}
+
+// ParseMarkup splits marked-up text into styled spans
+func ParseMarkup(markup string) []TextSpan {
+	spans := []TextSpan{}
+	stack := []markupState{{}}
+	current := []byte{}
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		spans = append(spans, TextSpan{Text: string(current), Style: top.style, Color: top.color, HasColor: top.hasColor})
+		current = current[:0]
+	}
+
+	for i := 0; i < len(markup); i++ {
+		if markup[i] != '[' {
+			current = append(current, markup[i])
+			continue
+		}
+		if strings.HasPrefix(markup[i:], "[[") {
+			current = append(current, '[')
+			i++
+			continue
+		}
+		end := strings.IndexByte(markup[i:], ']')
+		if end < 0 {
+			current = append(current, markup[i:]...)
+			break
+		}
+		tag := markup[i+1 : i+end]
+		top := stack[len(stack)-1]
+
+		// Closing tag pops the matching style
+		if strings.HasPrefix(tag, "/") {
+			name := tag[1:]
+			if len(stack) > 1 && top.tag == name {
+				flush()
+				stack = stack[:len(stack)-1]
+				i += end
+				continue
+			}
+			current = append(current, "["+tag+"]"...)
+			i += end
+			continue
+		}
+
+		next := top
+		next.tag = tag
+		switch {
+		case tag == "b":
+			next.style |= ttf.StyleBold
+		case tag == "i":
+			next.style |= ttf.StyleItalic
+		case tag == "u":
+			next.style |= ttf.StyleUnderline
+		case tag == "s":
+			next.style |= ttf.StyleStrikethrough
+		case strings.HasPrefix(tag, "color="):
+			color, ok := parseHexColor(strings.TrimPrefix(tag, "color="))
+			if !ok {
+				current = append(current, "["+tag+"]"...)
+				i += end
+				continue
+			}
+			next.tag = "color"
+			next.color = color
+			next.hasColor = true
+		default:
+			current = append(current, "["+tag+"]"...)
+			i += end
+			continue
+		}
+		flush()
+		stack = append(stack, next)
+		i += end
+	}
+	flush()
+	return spans
+}
+
+// Helper function to parse "#rgb", "#rrggbb" or "#rrggbbaa"
+func parseHexColor(value string) (sdl.Color, bool) {
+	value = strings.TrimPrefix(value, "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) == 6 {
+		value += "ff"
+	}
+	if len(value) != 8 {
+		return sdl.Color{}, false
+	}
+	n, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return sdl.Color{}, false
+	}
+	return sdl.Color{R: uint8(n >> 24), G: uint8(n >> 16), B: uint8(n >> 8), A: uint8(n)}, true
+}
+
+// StripMarkup returns the plain text of marked-up text
+func StripMarkup(markup string) string {
+	text := ""
+	for _, span := range ParseMarkup(markup) {
+		text += span.Text
+	}
+	return text
+}
+
+// MeasureSpans measures a line of spans (logical size)
+func MeasureSpans(font *ttf.Font, spans []TextSpan) (float32, float32) {
+	width := measureSpansPixels(font, spans)
+	return width / pixelDensity, fontHeight(font)
+}
+
+// Same as MeasureSpans but only the width, in physical pixels
+func measureSpansPixels(font *ttf.Font, spans []TextSpan) float32 {
+	var width float32
+	for _, span := range spans {
+		withFontStyle(font, span.Style, func(face *ttf.Font) {
+			width += measureTextPixels(face, span.Text)
+		})
+	}
+	return width
+}
+
+// Helper function to render a line of spans into one surface (caller destroys it)
+func renderSpansSurface(font *ttf.Font, spans []TextSpan, color sdl.Color) *sdl.Surface {
+	width := measureSpansPixels(font, spans)
+	if width <= 0 {
+		return nil
+	}
+	target := sdl.CreateSurface(int32(width)+1, ttf.GetFontHeight(font), sdl.PixelFormatARGB8888)
+	if target == nil {
+		return nil
+	}
+
+	x := int32(0)
+	for _, span := range spans {
+		spanColor := color
+		if span.HasColor {
+			spanColor = span.Color
+		}
+		withFontStyle(font, span.Style, func(face *ttf.Font) {
+			surface := renderTextSurface(face, span.Text, spanColor)
+			if surface == nil {
+				return
+			}
+			// Copy pixels as-is, blending onto the transparent target would darken the edges
+			sdl.SetSurfaceBlendMode(surface, sdl.BlendModeNone)
+			dst := sdl.Rect{X: x, Y: 0, W: surface.W, H: surface.H}
+			sdl.BlitSurface(surface, nil, target, &dst)
+			x += int32(measureTextPixels(face, span.Text))
+			sdl.DestroySurface(surface)
+		})
+	}
+	return target
+}
+
+// DrawSpans draws a line of spans, returns the drawn size
+func DrawSpans(renderer *sdl.Renderer, font *ttf.Font, spans []TextSpan, x, y float32, color sdl.Color) (float32, float32) {
+	texture, _, _ := textCache.Get(renderer, newTextCacheKey(font, spansCacheText(spans), color), func() (*sdl.Surface, int32, int32) {
+		return renderSpansSurface(font, spans, color), 0, 0
+	})
+	if texture == nil {
+		return 0, 0
+	}
+
+	textW, textH := textureLogicalSize(texture)
+	textRect := sdl.FRect{X: x, Y: y, W: textW, H: textH}
+	drawTexture(renderer, texture, nil, &textRect)
+	return textW, textH
+}
+
+// Helper function encoding spans as a texture cache key
+func spansCacheText(spans []TextSpan) string {
+	return fmt.Sprint(spans)
+}
+
+// WrapSpans wraps styled spans into lines that fit maxWidth.
+// Works like WrapText: explicit newlines break paragraphs, words wrap on spaces.
+func WrapSpans(spans []TextSpan, font *ttf.Font, maxWidth float32) [][]TextSpan {
+	lines := [][]TextSpan{}
+	line := []TextSpan{}
+	lineWidth := float32(0)
+
+	// Appends a word (or space) to the current line, merging with the last span if the style matches
+	appendPiece := func(piece TextSpan) {
+		if n := len(line); n > 0 && line[n-1].Style == piece.Style && line[n-1].HasColor == piece.HasColor && line[n-1].Color == piece.Color {
+			line[n-1].Text += piece.Text
+		} else {
+			line = append(line, piece)
+		}
+	}
+	breakLine := func() {
+		// Drop trailing spaces
+		for len(line) > 0 {
+			last := &line[len(line)-1]
+			last.Text = strings.TrimRight(last.Text, " ")
+			if last.Text != "" {
+				break
+			}
+			line = line[:len(line)-1]
+		}
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+		line = []TextSpan{}
+		lineWidth = 0
+	}
+
+	for _, span := range spans {
+		for p, paragraph := range strings.Split(span.Text, "\n") {
+			if p > 0 {
+				breakLine()
+			}
+			for w, word := range strings.Split(paragraph, " ") {
+				piece := span
+				piece.Text = word
+				if w > 0 {
+					piece.Text = " " + word
+				}
+				if lineWidth == 0 {
+					piece.Text = strings.TrimLeft(piece.Text, " ")
+				}
+				if piece.Text == "" {
+					continue
+				}
+
+				var pieceWidth float32
+				withFontStyle(font, span.Style, func(face *ttf.Font) {
+					pieceWidth = MeasureText(face, piece.Text)
+				})
+				if lineWidth+pieceWidth > maxWidth && lineWidth > 0 {
+					// Word doesn't fit, start new line
+					breakLine()
+					piece.Text = strings.TrimLeft(piece.Text, " ")
+					withFontStyle(font, span.Style, func(face *ttf.Font) {
+						pieceWidth = MeasureText(face, piece.Text)
+					})
+				}
+				appendPiece(piece)
+				lineWidth += pieceWidth
+			}
+		}
+	}
+	breakLine()
+	return lines
+}
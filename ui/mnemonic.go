@@ -0,0 +1,71 @@
+// mnemonic.go
+package ui
+
+// Mnemonics: an ampersand in a button caption ("&Save") underlines the next
+// letter and Alt with that letter clicks the button, like the accelerators
+// of desktop menus. "&&" shows a literal ampersand.
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Mnemonic is implemented by widgets activated with Alt and a letter
+type Mnemonic interface {
+	Activatable
+	Mnemonic() rune // Lower case letter, 0 for none
+}
+
+// Helper function splitting the mnemonic marker off caption: the text to show,
+// the lower cased letter after the first single "&" (0 if none) and its byte
+// offset in the shown text
+func parseMnemonic(caption string) (text string, mnemonic rune, index int) {
+	if !strings.Contains(caption, "&") {
+		return caption, 0, -1
+	}
+	var shown strings.Builder
+	index = -1
+	for i := 0; i < len(caption); i++ {
+		if caption[i] != '&' || i+1 == len(caption) {
+			shown.WriteByte(caption[i])
+			continue
+		}
+		i++ // Skip the marker
+		if caption[i] != '&' && mnemonic == 0 {
+			letter, _ := utf8.DecodeRuneInString(caption[i:])
+			mnemonic, index = unicode.ToLower(letter), shown.Len()
+		}
+		shown.WriteByte(caption[i])
+	}
+	return shown.String(), mnemonic, index
+}
+
+// Helper function returning the enabled widget below widgets with the
+// mnemonic of an Alt+letter key press, or nil
+func mnemonicFor(widgets []Widget, key sdl.KeyboardEvent) Mnemonic {
+	if key.Mod&sdl.KeymodAlt == 0 || key.Mod&(sdl.KeymodCtrl|sdl.KeymodGui) != 0 {
+		return nil
+	}
+	letter := unicode.ToLower(rune(key.Key))
+	if !unicode.IsPrint(letter) {
+		return nil
+	}
+	for _, widget := range widgets {
+		if owner, ok := widget.(Mnemonic); ok && owner.Mnemonic() == letter {
+			if button, ok := widget.(*Button); !ok || !button.Disabled {
+				return owner
+			}
+		}
+		if inner := mnemonicFor(widgetChildren(widget), key); inner != nil {
+			return inner
+		}
+	}
+	return nil
+}
+
+func (b *Button) Mnemonic() rune {
+	return b.mnemonic
+}
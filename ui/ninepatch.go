@@ -0,0 +1,40 @@
+// ninepatch.go
+package ui
+
+// Nine-slice (nine-patch) rendering: the corners of a skin texture keep their
+// size, the edges stretch along one axis and the center stretches both ways,
+// so one small texture can skin buttons and panels of any size.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// NinePatch draws a texture split into a 3x3 grid
+type NinePatch struct {
+	Texture *sdl.Texture
+	Source  *sdl.FRect // Region of the texture to use (nil for the whole texture)
+	Left    float32    // Sizes of the fixed borders, in texture pixels
+	Right   float32
+	Top     float32
+	Bottom  float32
+	Scale   float32 // Scale of the fixed borders (0 means 1)
+}
+
+func NewNinePatch(texture *sdl.Texture, left, right, top, bottom float32) *NinePatch {
+	return &NinePatch{Texture: texture, Left: left, Right: right, Top: top, Bottom: bottom, Scale: 1}
+}
+
+// Render draws the patch stretched over dst
+func (p *NinePatch) Render(renderer *sdl.Renderer, dst sdl.FRect) {
+	if p.Texture == nil || dst.W <= 0 || dst.H <= 0 {
+		return
+	}
+	scale := p.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	FlushBatch()
+	sdl.SetTextureAlphaMod(p.Texture, fadeAlpha(sdl.AlphaOpaque))
+	sdl.SetTextureBlendMode(p.Texture, drawBlendMode)
+	sdl.RenderTexture9Grid(renderer, p.Texture, p.Source, p.Left, p.Right, p.Top, p.Bottom, scale, &dst)
+}
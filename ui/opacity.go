@@ -0,0 +1,89 @@
+// opacity.go
+package ui
+
+// Widget opacity: containers multiply their opacity into everything their
+// children draw, so a whole subtree can fade in and out or be dimmed.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Opacity of the widgets currently being rendered, applied by the drawing helpers
+var inheritedOpacity float32 = 1
+
+// PushOpacity multiplies opacity into the inherited opacity and returns a function
+// restoring it. Widgets use it as `defer PushOpacity(w.Opacity)()` in Render.
+func PushOpacity(opacity float32) func() {
+	previous := inheritedOpacity
+	inheritedOpacity *= max(0, min(opacity, 1))
+	return func() {
+		inheritedOpacity = previous
+	}
+}
+
+// Helper function applying the inherited opacity to an alpha value
+func fadeAlpha(alpha uint8) uint8 {
+	return uint8(float32(alpha) * inheritedOpacity)
+}
+
+// SetDrawColor sets the draw color with the inherited opacity and the current blend mode
+func SetDrawColor(renderer *sdl.Renderer, r, g, b, a uint8) {
+	FlushBatch() // Direct draw calls follow
+	sdl.SetRenderDrawBlendMode(renderer, drawBlendMode)
+	sdl.SetRenderDrawColor(renderer, r, g, b, fadeAlpha(a))
+}
+
+// Helper function drawing a texture with the inherited opacity and the current blend mode
+// (queued into the shared batch while batching, with the texture's color mod as tint)
+func drawTexture(renderer *sdl.Renderer, texture *sdl.Texture, src, dst *sdl.FRect) {
+	if batching > 0 && dst != nil {
+		tint := sdl.Color{A: sdl.AlphaOpaque}
+		sdl.GetTextureColorMod(texture, &tint.R, &tint.G, &tint.B)
+		frameBatch.Texture(texture, src, *dst, tint)
+		return
+	}
+	sdl.SetTextureAlphaMod(texture, fadeAlpha(sdl.AlphaOpaque))
+	sdl.SetTextureBlendMode(texture, drawBlendMode)
+	sdl.RenderTexture(renderer, texture, src, dst)
+}
+
+// Fade animates an opacity between hidden (0) and visible (1)
+type Fade struct {
+	Value    float32 // Current opacity
+	Duration float32 // Seconds for a complete fade
+	visible  bool    // Target state
+}
+
+func NewFade(duration float32, visible bool) *Fade {
+	fade := &Fade{Duration: duration, visible: visible}
+	if visible {
+		fade.Value = 1
+	}
+	return fade
+}
+
+// SetVisible starts fading in or out
+func (f *Fade) SetVisible(visible bool) {
+	f.visible = visible
+}
+
+// Tick advances the fade by dt seconds, returns true while it is changing
+func (f *Fade) Tick(dt float32) bool {
+	target := float32(0)
+	if f.visible {
+		target = 1
+	}
+	if f.Value == target {
+		return false
+	}
+	step := float32(1)
+	if f.Duration > 0 {
+		step = dt / f.Duration
+	}
+	if f.Value < target {
+		f.Value = min(f.Value+step, target)
+	} else {
+		f.Value = max(f.Value-step, target)
+	}
+	return true
+}
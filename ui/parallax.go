@@ -0,0 +1,76 @@
+// parallax.go
+package ui
+
+// Parallax backdrops: texture layers scroll slower (or faster) than the
+// world as the camera moves, giving game-style depth.
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// ParallaxLayer is one texture of a parallax background
+type ParallaxLayer struct {
+	Texture *sdl.Texture
+	Ratio   float32 // Scroll speed relative to the camera: 0 fixed on screen, 1 moves with the world
+	OffsetX float32 // Position of the texture at camera position 0, 0 (screen units)
+	OffsetY float32
+	Scale   float32 // Texture size multiplier at zoom 1
+	RepeatX bool    // Tile horizontally across the viewport
+	RepeatY bool    // Tile vertically across the viewport
+}
+
+// ParallaxBackground draws its layers back to front
+type ParallaxBackground struct {
+	Layers []*ParallaxLayer
+}
+
+func NewParallaxBackground() *ParallaxBackground {
+	return &ParallaxBackground{}
+}
+
+// AddLayer adds a layer in front of the existing ones, repeating horizontally
+func (p *ParallaxBackground) AddLayer(texture *sdl.Texture, ratio float32) *ParallaxLayer {
+	layer := &ParallaxLayer{Texture: texture, Ratio: ratio, Scale: 1, RepeatX: true}
+	p.Layers = append(p.Layers, layer)
+	return layer
+}
+
+// Render draws the layers for the camera position (call between camera Begin and End)
+func (p *ParallaxBackground) Render(renderer *sdl.Renderer, camera *Camera2D) {
+	viewW, viewH := camera.viewportSize(renderer)
+	for _, layer := range p.Layers {
+		if layer.Texture == nil {
+			continue
+		}
+		// Distant layers also react less to zoom
+		zoom := 1 + (camera.Zoom-1)*layer.Ratio
+		var texW, texH float32
+		sdl.GetTextureSize(layer.Texture, &texW, &texH)
+		w, h := texW*layer.Scale*zoom, texH*layer.Scale*zoom
+		if w <= 0 || h <= 0 {
+			continue
+		}
+		x := layer.OffsetX - camera.X*layer.Ratio*camera.Zoom
+		y := layer.OffsetY - camera.Y*layer.Ratio*camera.Zoom
+
+		// Start from the first copy reaching into the viewport
+		startX, endX := x, x
+		if layer.RepeatX {
+			startX = x - w*float32(math.Ceil(float64(x/w)))
+			endX = viewW
+		}
+		startY, endY := y, y
+		if layer.RepeatY {
+			startY = y - h*float32(math.Ceil(float64(y/h)))
+			endY = viewH
+		}
+		for ty := startY; ty <= endY; ty += h {
+			for tx := startX; tx <= endX; tx += w {
+				dst := sdl.FRect{X: tx, Y: ty, W: w, H: h}
+				drawTexture(renderer, layer.Texture, nil, &dst)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// path.go
+package ui
+
+// Vector paths built from lines and Bezier curves, e.g. connectors,
+// signatures and node-graph edges. Curves are flattened into short line
+// segments and drawn with the polyline and polygon helpers.
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Length in pixels of the segments curves are flattened into
+const curveTolerance = 4
+
+// Path is a list of subpaths, each started by MoveTo
+type Path struct {
+	subpaths []pathPart
+}
+
+// One continuous run of points
+type pathPart struct {
+	points []sdl.FPoint
+	closed bool
+}
+
+func NewPath() *Path {
+	return &Path{}
+}
+
+// Helper function returning the subpath being extended, starting one at 0, 0 if needed
+func (p *Path) current() *pathPart {
+	if len(p.subpaths) == 0 {
+		p.MoveTo(0, 0)
+	}
+	return &p.subpaths[len(p.subpaths)-1]
+}
+
+// Helper function returning the last point of the path
+func (p *Path) last() sdl.FPoint {
+	part := p.current()
+	return part.points[len(part.points)-1]
+}
+
+// MoveTo starts a new subpath at x, y
+func (p *Path) MoveTo(x, y float32) *Path {
+	p.subpaths = append(p.subpaths, pathPart{points: []sdl.FPoint{{X: x, Y: y}}})
+	return p
+}
+
+// LineTo adds a straight line to x, y
+func (p *Path) LineTo(x, y float32) *Path {
+	part := p.current()
+	part.points = append(part.points, sdl.FPoint{X: x, Y: y})
+	return p
+}
+
+// QuadTo adds a quadratic Bezier curve with control point cx, cy ending at x, y
+func (p *Path) QuadTo(cx, cy, x, y float32) *Path {
+	start := p.last()
+	// Same curve as a cubic with control points two thirds of the way to cx, cy
+	return p.CurveTo(
+		start.X+(cx-start.X)*2/3, start.Y+(cy-start.Y)*2/3,
+		x+(cx-x)*2/3, y+(cy-y)*2/3,
+		x, y)
+}
+
+// CurveTo adds a cubic Bezier curve with control points c1 and c2 ending at x, y
+func (p *Path) CurveTo(c1x, c1y, c2x, c2y, x, y float32) *Path {
+	start := p.last()
+	c1, c2, end := sdl.FPoint{X: c1x, Y: c1y}, sdl.FPoint{X: c2x, Y: c2y}, sdl.FPoint{X: x, Y: y}
+
+	// The control polygon is never shorter than the curve
+	length := distance(start, c1) + distance(c1, c2) + distance(c2, end)
+	steps := max(1, int(math.Ceil(float64(length/curveTolerance))))
+
+	part := p.current()
+	for i := 1; i <= steps; i++ {
+		part.points = append(part.points, cubicPoint(start, c1, c2, end, float32(i)/float32(steps)))
+	}
+	return p
+}
+
+// Close joins the current subpath back to its start
+func (p *Path) Close() *Path {
+	p.current().closed = true
+	return p
+}
+
+// Stroke draws the path outline
+func (p *Path) Stroke(renderer *sdl.Renderer, thickness float32, color sdl.Color) {
+	for _, part := range p.subpaths {
+		segments := len(part.points) - 1
+		if part.closed {
+			segments++
+		}
+		for i := range segments {
+			a, b := part.points[i], part.points[(i+1)%len(part.points)]
+			DrawLine(renderer, a.X, a.Y, b.X, b.Y, thickness, color)
+		}
+		// Round joints fill the gaps between thick segments
+		if thickness > 1 {
+			for _, point := range part.points {
+				FillCircle(renderer, point.X, point.Y, thickness/2, color)
+			}
+		}
+	}
+}
+
+// Fill fills each subpath as a closed polygon
+func (p *Path) Fill(renderer *sdl.Renderer, color sdl.Color) {
+	for _, part := range p.subpaths {
+		FillPolygon(renderer, part.points, color)
+	}
+}
+
+// Helper function returning the distance between two points
+func distance(a, b sdl.FPoint) float32 {
+	return float32(math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y)))
+}
+
+// Helper function evaluating a cubic Bezier curve at t (0 to 1)
+func cubicPoint(p0, p1, p2, p3 sdl.FPoint, t float32) sdl.FPoint {
+	u := 1 - t
+	a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+	return sdl.FPoint{
+		X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+		Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+	}
+}
+
+// ConnectorPath returns a horizontal S-curve between two points, as used for node-graph edges
+func ConnectorPath(x1, y1, x2, y2 float32) *Path {
+	bend := max(float32(math.Abs(float64(x2-x1)))/2, 20)
+	return NewPath().MoveTo(x1, y1).CurveTo(x1+bend, y1, x2-bend, y2, x2, y2)
+}
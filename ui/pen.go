@@ -0,0 +1,129 @@
+// pen.go
+package ui
+
+// Pen and stylus input: SDL reports pen motion, contact and axes (pressure,
+// tilt) as separate events, the PenTracker combines them into the state of
+// each pen and hands it to the PenReceiver widget under the pen, e.g. a
+// canvas drawing strokes that get thicker with pressure. Without a receiver
+// SDL's mouse events made up from the pen still click and drag widgets.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// PenState is what a pen last reported
+type PenState struct {
+	ID           sdl.PenID
+	X, Y         float32 // Logical units
+	Pressure     float32 // 0 to 1, 0 while the pen doesn't touch
+	TiltX, TiltY float32 // Degrees from upright, -90 to 90
+	Down         bool    // Touches the tablet or screen
+	Eraser       bool    // The eraser end is used
+	Buttons      uint8   // Barrel buttons held, bit 0 is button 1
+}
+
+// PenReceiver is implemented by widgets drawing with pens, Pen gets the state
+// on every change and returns true if the widget used it
+type PenReceiver interface {
+	Widget
+	Pen(state PenState) bool
+}
+
+// PenTracker follows the pens over the window. Give it every event before the
+// widgets, a pen touching down on a receiver keeps sending to it until it lifts.
+type PenTracker struct {
+	Roots []Widget
+	OnPen func(state PenState) // Every change, whether a widget used it or not
+	pens  map[sdl.PenID]*PenState
+	drawn PenReceiver // Receiver the pen touched down on
+}
+
+func NewPenTracker(roots ...Widget) *PenTracker {
+	return &PenTracker{Roots: roots, pens: make(map[sdl.PenID]*PenState)}
+}
+
+// State returns the last state of a pen near the window
+func (p *PenTracker) State(id sdl.PenID) (PenState, bool) {
+	if state, ok := p.pens[id]; ok {
+		return *state, true
+	}
+	return PenState{}, false
+}
+
+// Update records pen events, returns true if a receiver used the event
+func (p *PenTracker) Update(event sdl.Event, mx, my float32) bool {
+	var state *PenState
+	switch event.Type() {
+	case sdl.EventPenProximityIn:
+		id := event.PProximity().Which
+		p.pens[id] = &PenState{ID: id}
+		return false
+	case sdl.EventPenProximityOut:
+		delete(p.pens, event.PProximity().Which)
+		return false
+	case sdl.EventPenMotion:
+		motion := event.PMotion()
+		state = p.pen(motion.Which, motion.PenState)
+		state.X, state.Y = motion.X, motion.Y
+	case sdl.EventPenDown, sdl.EventPenUp:
+		touch := event.PTouch()
+		state = p.pen(touch.Which, touch.PenState)
+		state.X, state.Y = touch.X, touch.Y
+		state.Down, state.Eraser = touch.Down, touch.Eraser
+		if !touch.Down {
+			state.Pressure = 0
+		}
+	case sdl.EventPenButtonDown, sdl.EventPenButtonUp:
+		button := event.PButton()
+		state = p.pen(button.Which, button.PenState)
+		state.X, state.Y = button.X, button.Y
+	case sdl.EventPenAxis:
+		axis := event.PAxis()
+		state = p.pen(axis.Which, axis.PenState)
+		state.X, state.Y = axis.X, axis.Y
+		switch axis.Axis {
+		case sdl.PenAxisPressure:
+			state.Pressure = axis.Value
+		case sdl.PenAxisXTilt:
+			state.TiltX = axis.Value
+		case sdl.PenAxisYTilt:
+			state.TiltY = axis.Value
+		}
+	default:
+		return false
+	}
+
+	if p.OnPen != nil {
+		p.OnPen(*state)
+	}
+	receiver := p.drawn
+	if receiver == nil {
+		receiver, _ = widgetAtMatching(p.Roots, sdl.FPoint{X: state.X, Y: state.Y}, isPenReceiver).(PenReceiver)
+	}
+	if event.Type() == sdl.EventPenDown {
+		p.drawn = receiver
+	} else if event.Type() == sdl.EventPenUp {
+		p.drawn = nil
+	}
+	return receiver != nil && receiver.Pen(*state)
+}
+
+// Helper function returning the state of pen id (also for pens that were
+// near before the tracker started), with the flags of its event applied
+func (p *PenTracker) pen(id sdl.PenID, flags sdl.PenInputFlags) *PenState {
+	state, ok := p.pens[id]
+	if !ok {
+		state = &PenState{ID: id}
+		p.pens[id] = state
+	}
+	state.Down = flags&sdl.PenInputDown != 0
+	state.Eraser = flags&sdl.PenInputEraserTip != 0
+	state.Buttons = uint8(flags >> 1 & 0x1F) // PenInputButton1 to 5
+	return state
+}
+
+// Helper function checking whether widget takes pen input
+func isPenReceiver(widget Widget) bool {
+	_, ok := widget.(PenReceiver)
+	return ok
+}
@@ -0,0 +1,137 @@
+// polygon.go
+package ui
+
+// Polygon drawing for custom widget shapes (arrows, chevrons, speech
+// bubbles). Simple polygons, convex or concave, are split into triangles by
+// ear clipping and drawn with RenderGeometry.
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Helper function returning twice the signed area of a polygon (positive when clockwise on screen)
+func polygonArea(points []sdl.FPoint) float32 {
+	var area float32
+	for i := range points {
+		a, b := points[i], points[(i+1)%len(points)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area
+}
+
+// Helper function returning the cross product of ab and ac
+func cross(a, b, c sdl.FPoint) float32 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// Helper function reporting whether p lies inside (or on) triangle abc of the given winding
+func pointInTriangle(p, a, b, c sdl.FPoint, winding float32) bool {
+	return cross(a, b, p)*winding >= 0 && cross(b, c, p)*winding >= 0 && cross(c, a, p)*winding >= 0
+}
+
+// triangulatePolygon returns triangle indices covering a simple polygon (no self-intersections)
+func triangulatePolygon(points []sdl.FPoint) []int32 {
+	if len(points) < 3 {
+		return nil
+	}
+	winding := float32(1)
+	if polygonArea(points) < 0 {
+		winding = -1
+	}
+
+	// Vertices not yet cut off
+	remaining := make([]int32, len(points))
+	for i := range remaining {
+		remaining[i] = int32(i)
+	}
+	indices := make([]int32, 0, (len(points)-2)*3)
+
+	for len(remaining) > 3 {
+		found := false
+		for i := range remaining {
+			prev := remaining[(i+len(remaining)-1)%len(remaining)]
+			curr := remaining[i]
+			next := remaining[(i+1)%len(remaining)]
+			a, b, c := points[prev], points[curr], points[next]
+			if cross(a, b, c)*winding <= 0 {
+				continue // Reflex (or flat) corner, not an ear
+			}
+			// An ear contains no other vertex
+			ear := true
+			for _, other := range remaining {
+				if other != prev && other != curr && other != next && pointInTriangle(points[other], a, b, c, winding) {
+					ear = false
+					break
+				}
+			}
+			if ear {
+				indices = append(indices, prev, curr, next)
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			break // Degenerate or self-intersecting, draw what was found
+		}
+	}
+	if len(remaining) == 3 {
+		indices = append(indices, remaining...)
+	}
+	return indices
+}
+
+// FillPolygon fills a simple polygon given by its corners in order
+func FillPolygon(renderer *sdl.Renderer, points []sdl.FPoint, color sdl.Color) {
+	indices := triangulatePolygon(points)
+	if len(indices) == 0 {
+		return
+	}
+	fcolor := toFColor(color)
+	vertices := make([]sdl.Vertex, len(points))
+	for i, point := range points {
+		vertices[i] = sdl.Vertex{Position: point, Color: fcolor}
+	}
+	renderBlendedGeometry(renderer, vertices, indices)
+}
+
+// DrawPolygon draws the anti-aliased outline of a polygon
+func DrawPolygon(renderer *sdl.Renderer, points []sdl.FPoint, thickness float32, color sdl.Color) {
+	if len(points) < 2 {
+		return
+	}
+	for i := range points {
+		a, b := points[i], points[(i+1)%len(points)]
+		DrawLine(renderer, a.X, a.Y, b.X, b.Y, thickness, color)
+	}
+	// Round joints fill the gaps between thick edges
+	if thickness > 1 {
+		for _, point := range points {
+			FillCircle(renderer, point.X, point.Y, thickness/2, color)
+		}
+	}
+}
+
+// ArrowPoints returns the outline of an arrow from x1, y1 to x2, y2 with the given shaft and head sizes
+func ArrowPoints(x1, y1, x2, y2, shaftWidth, headWidth, headLength float32) []sdl.FPoint {
+	dx, dy := x2-x1, y2-y1
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length == 0 {
+		return nil
+	}
+	ux, uy := dx/length, dy/length // Along the arrow
+	nx, ny := -uy, ux              // Across the arrow
+	headLength = min(headLength, length)
+	baseX, baseY := x2-ux*headLength, y2-uy*headLength
+	return []sdl.FPoint{
+		{X: x1 + nx*shaftWidth/2, Y: y1 + ny*shaftWidth/2},
+		{X: baseX + nx*shaftWidth/2, Y: baseY + ny*shaftWidth/2},
+		{X: baseX + nx*headWidth/2, Y: baseY + ny*headWidth/2},
+		{X: x2, Y: y2},
+		{X: baseX - nx*headWidth/2, Y: baseY - ny*headWidth/2},
+		{X: baseX - nx*shaftWidth/2, Y: baseY - ny*shaftWidth/2},
+		{X: x1 - nx*shaftWidth/2, Y: y1 - ny*shaftWidth/2},
+	}
+}
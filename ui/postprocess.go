@@ -0,0 +1,133 @@
+// postprocess.go
+package ui
+
+// Full-frame post-processing: the scene is drawn into an offscreen target and
+// composited with effects (blur behind dialogs, vignette, grayscale for a
+// "disabled app" look). Effects are toggled at runtime through the fields.
+
+import (
+	"math"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// PostProcessor applies effects to everything drawn between Begin and End
+type PostProcessor struct {
+	Blur      float32 // Downscale factor used for blurring, values above 1 blur (e.g. 6)
+	Vignette  float32 // Darkness of the edges, 0 to 1
+	Grayscale bool    // Desaturate the frame (read back on the CPU, meant for static states)
+
+	scene  RenderTarget
+	blur   RenderTarget
+	width  float32 // Logical frame size of the current frame
+	height float32
+	active bool // Begin redirected drawing into the scene target
+}
+
+func NewPostProcessor() *PostProcessor {
+	return &PostProcessor{}
+}
+
+// Enabled reports whether any effect is on
+func (p *PostProcessor) Enabled() bool {
+	return p.Blur > 1 || p.Vignette > 0 || p.Grayscale
+}
+
+// Begin starts drawing the scene, call it before clearing the frame
+func (p *PostProcessor) Begin(renderer *sdl.Renderer, width, height float32) {
+	p.active = p.Enabled() && p.scene.Ensure(renderer, width, height)
+	if !p.active {
+		return
+	}
+	p.width, p.height = width, height
+	p.scene.Begin(renderer)
+}
+
+// End composites the scene with the effects, drawing after End is not affected (e.g. dialogs)
+func (p *PostProcessor) End(renderer *sdl.Renderer) {
+	FlushBatch()
+	if !p.active {
+		return
+	}
+	p.active = false
+
+	source := p.scene.Texture
+	if p.Grayscale {
+		if gray := grayscaleTexture(renderer); gray != nil {
+			source = gray
+			defer sdl.DestroyTexture(gray)
+		}
+	}
+	p.scene.End(renderer)
+
+	full := sdl.FRect{X: 0, Y: 0, W: p.width, H: p.height}
+	src := sdl.FRect{X: 0, Y: 0, W: p.width * pixelDensity, H: p.height * pixelDensity}
+	if p.Blur > 1 {
+		// Scaling down and back up with linear filtering averages neighbouring pixels
+		small := sdl.FRect{X: 0, Y: 0, W: p.width / p.Blur, H: p.height / p.Blur}
+		if p.blur.Ensure(renderer, small.W, small.H) {
+			sdl.SetTextureScaleMode(source, sdl.ScaleModeLinear)
+			sdl.SetTextureScaleMode(p.blur.Texture, sdl.ScaleModeLinear)
+			p.blur.Begin(renderer)
+			sdl.RenderTexture(renderer, source, &src, &small)
+			p.blur.End(renderer)
+			p.blur.Draw(renderer, &small, &full)
+		} else {
+			sdl.RenderTexture(renderer, source, &src, &full)
+		}
+	} else {
+		sdl.RenderTexture(renderer, source, &src, &full)
+	}
+
+	if p.Vignette > 0 {
+		drawVignette(renderer, p.width, p.height, p.Vignette)
+	}
+}
+
+// Helper function returning a desaturated copy of the current render target (caller destroys it)
+func grayscaleTexture(renderer *sdl.Renderer) *sdl.Texture {
+	pixels := sdl.RenderReadPixels(renderer, nil)
+	if pixels == nil {
+		return nil
+	}
+	defer sdl.DestroySurface(pixels)
+	surface := sdl.ConvertSurface(pixels, sdl.PixelFormatARGB8888)
+	if surface == nil {
+		return nil
+	}
+	defer sdl.DestroySurface(surface)
+
+	sdl.LockSurface(surface)
+	for y := int32(0); y < surface.H; y++ {
+		row := unsafe.Slice((*uint32)(unsafe.Add(surface.Pixels, uintptr(y*surface.Pitch))), surface.W)
+		for x, pixel := range row {
+			// Rec. 601 luma
+			luma := (299*(pixel>>16&0xff) + 587*(pixel>>8&0xff) + 114*(pixel&0xff)) / 1000
+			row[x] = pixel&0xff000000 | luma<<16 | luma<<8 | luma
+		}
+	}
+	sdl.UnlockSurface(surface)
+	return sdl.CreateTextureFromSurface(renderer, surface)
+}
+
+// Helper function darkening the frame edges with a radial gradient
+func drawVignette(renderer *sdl.Renderer, width, height, strength float32) {
+	const segments = 64
+	cx, cy := width/2, height/2
+	clear := sdl.FColor{}
+	dark := sdl.FColor{A: min(strength, 1)}
+
+	// Fade from a clear inner ellipse to an outer one through the frame corners
+	rx, ry := cx*math.Sqrt2, cy*math.Sqrt2
+	vertices := make([]sdl.Vertex, 0, segments*2)
+	vertices = appendRing(vertices, ellipsePoints(cx, cy, rx*0.5, ry*0.5, segments), clear)
+	vertices = appendRing(vertices, ellipsePoints(cx, cy, rx, ry, segments), dark)
+	indices := appendRingStrip(make([]int32, 0, segments*6), 0, segments, segments)
+	renderBlendedGeometry(renderer, vertices, indices)
+}
+
+func (p *PostProcessor) Destroy() {
+	p.scene.Destroy()
+	p.blur.Destroy()
+}
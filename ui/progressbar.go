@@ -0,0 +1,59 @@
+// progressbar.go
+package ui
+
+// Horizontal bar showing how far some work has come (0 to 1)
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// ProgressBar widget
+type ProgressBar struct {
+	Bounds     sdl.FRect
+	Value      float32 // 0 (empty) to 1 (full)
+	Color      sdl.Color
+	Background sdl.Color
+	Opacity    float32 // 0 (invisible) to 1 (opaque)
+	Dirty
+}
+
+func NewProgressBar(x, y, w, h float32) *ProgressBar {
+	return &ProgressBar{
+		Bounds:     sdl.FRect{X: x, Y: y, W: w, H: h},
+		Color:      sdl.Color{R: 70, G: 160, B: 90, A: 255},
+		Background: sdl.Color{R: 80, G: 80, B: 80, A: 255},
+		Opacity:    1,
+	}
+}
+
+// SetValue changes the shown progress, clamped to 0..1
+func (p *ProgressBar) SetValue(value float32) {
+	value = max(0, min(value, 1))
+	if value != p.Value {
+		p.Value = value
+		p.MarkDirty()
+	}
+}
+
+func (p *ProgressBar) Update(event sdl.Event, mx, my float32) bool {
+	return false
+}
+
+func (p *ProgressBar) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(p.Opacity)()
+	radius := min(6, p.Bounds.H/2)
+	FillRoundedRect(renderer, p.Bounds, radius, p.Background)
+	if p.Value > 0 {
+		filled := p.Bounds
+		filled.W = max(p.Bounds.W*p.Value, 2*radius) // Keep the rounded ends intact
+		FillRoundedRect(renderer, filled, radius, p.Color)
+	}
+}
+
+func (p *ProgressBar) GetBounds() sdl.FRect {
+	return p.Bounds
+}
+
+func (p *ProgressBar) SetPosition(x, y float32) {
+	p.Bounds.X, p.Bounds.Y = x, y
+}
@@ -0,0 +1,57 @@
+// registry.go
+package ui
+
+// Widget types by name, used by UI files. Packages outside ui register their
+// own widgets from an init function, the loader then accepts them like the
+// built-in ones.
+
+import (
+	"fmt"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// WidgetSpec holds the attributes of a widget in a UI file
+type WidgetSpec struct {
+	Text   string         `json:"text"`
+	Action string         `json:"action"`
+	X      float32        `json:"x"` // Position of the root, children are placed by their layout
+	Y      float32        `json:"y"`
+	W      float32        `json:"w"` // 0 sizes buttons to their text
+	H      float32        `json:"h"`
+	Props  map[string]any `json:"props"` // Attributes of registered widget types
+
+	// Set by the loader
+	Font     *ttf.Font     `json:"-"`
+	Renderer *sdl.Renderer `json:"-"`
+	Window   *sdl.Window   `json:"-"`
+}
+
+// WidgetFactory creates a widget from its spec
+type WidgetFactory func(spec WidgetSpec) (Widget, error)
+
+var widgetFactories = map[string]WidgetFactory{
+	"label": func(spec WidgetSpec) (Widget, error) {
+		return NewLabel(spec.X, spec.Y, spec.Text, spec.Font, spec.Renderer), nil
+	},
+	"button": func(spec WidgetSpec) (Widget, error) {
+		button := NewButton(spec.X, spec.Y, spec.W, spec.H, spec.Text, spec.Font, spec.Renderer, nil)
+		button.Action = spec.Action
+		return button, nil
+	},
+	"input": func(spec WidgetSpec) (Widget, error) {
+		input := NewTextInput(spec.X, spec.Y, spec.W, spec.Font, spec.Renderer, spec.Window)
+		input.SetText(spec.Text)
+		return input, nil
+	},
+}
+
+// RegisterWidget makes a widget type available to UI files under name.
+// It panics if the name is taken ("row" and "column" are reserved for layouts).
+func RegisterWidget(name string, factory WidgetFactory) {
+	if _, taken := widgetFactories[name]; taken || name == "row" || name == "column" {
+		panic(fmt.Sprintf("ui: widget type %q registered twice", name))
+	}
+	widgetFactories[name] = factory
+}
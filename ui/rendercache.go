@@ -0,0 +1,157 @@
+// rendercache.go
+package ui
+
+// Render caching: a container with CacheRender set draws its widgets into a
+// texture once and reuses it until one of them marks itself dirty, or until
+// all caches are invalidated (theme or display scale change).
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Dirty can be embedded in widgets to report visual changes to cached parents
+type Dirty struct {
+	dirty bool
+}
+
+// MarkDirty flags the widget as needing to be redrawn
+func (d *Dirty) MarkDirty() {
+	d.dirty = true
+}
+
+// takeDirty returns the dirty flag and resets it
+func (d *Dirty) takeDirty() bool {
+	wasDirty := d.dirty
+	d.dirty = false
+	return wasDirty
+}
+
+type dirtyReporter interface {
+	takeDirty() bool
+}
+
+// Bumped whenever every cache must be thrown away
+var renderCacheGeneration uint64
+
+// InvalidateRenderCaches forces all cached containers to re-render,
+// call it after theme or DPI changes
+func InvalidateRenderCaches() {
+	renderCacheGeneration++
+}
+
+// RenderCache holds the cached texture of a widget subtree
+type RenderCache struct {
+	RenderTarget
+	bounds     sdl.FRect
+	generation uint64
+	stale      bool // Set by Invalidate
+}
+
+// Invalidate makes the next Render redraw the widgets
+func (c *RenderCache) Invalidate() {
+	c.stale = true
+}
+
+// Helper function returning the union of the bounds of the given widgets
+func widgetsBounds(widgets []Widget) sdl.FRect {
+	if len(widgets) == 0 {
+		return sdl.FRect{}
+	}
+	bounds := widgets[0].GetBounds()
+	for _, widget := range widgets[1:] {
+		b := widget.GetBounds()
+		minX, minY := min(bounds.X, b.X), min(bounds.Y, b.Y)
+		maxX := max(bounds.X+bounds.W, b.X+b.W)
+		maxY := max(bounds.Y+bounds.H, b.Y+b.H)
+		bounds = sdl.FRect{X: minX, Y: minY, W: maxX - minX, H: maxY - minY}
+	}
+	return bounds
+}
+
+// Render draws the widgets through the cache, re-rendering them into the
+// cache texture only when something changed
+func (c *RenderCache) Render(renderer *sdl.Renderer, widgets []Widget) {
+	// Collect dirty flags from every widget (so none stay set for the next frame)
+	dirty := c.Texture == nil || c.stale || c.generation != renderCacheGeneration
+	for _, widget := range widgets {
+		if reporter, ok := widget.(dirtyReporter); ok && reporter.takeDirty() {
+			dirty = true
+		}
+	}
+
+	bounds := widgetsBounds(widgets)
+	if bounds.W <= 0 || bounds.H <= 0 {
+		return
+	}
+	if bounds != c.bounds {
+		dirty = true
+	}
+
+	if dirty {
+		// The texture covers everything from the origin so widgets can keep
+		// drawing with window coordinates
+		if !c.Ensure(renderer, bounds.X+bounds.W, bounds.Y+bounds.H) {
+			// Render targets unsupported, draw directly
+			for _, widget := range widgets {
+				widget.Render(renderer)
+			}
+			return
+		}
+
+		// Cached at full opacity with normal blending, the inherited opacity
+		// and blend mode apply to the composited texture
+		opacity := inheritedOpacity
+		inheritedOpacity = 1
+		restoreBlendMode := PushBlendMode(sdl.BlendModeBlend)
+		c.Begin(renderer)
+		for _, widget := range widgets {
+			widget.Render(renderer)
+		}
+		c.End(renderer)
+		restoreBlendMode()
+		inheritedOpacity = opacity
+
+		c.bounds = bounds
+		c.generation = renderCacheGeneration
+		c.stale = false
+	}
+
+	c.Draw(renderer, &bounds, &bounds)
+}
+
+// CachedWidget wraps any widget (e.g. a nested layout) and draws it through a RenderCache
+type CachedWidget struct {
+	Widget
+	cache RenderCache
+}
+
+func NewCachedWidget(widget Widget) *CachedWidget {
+	return &CachedWidget{Widget: widget}
+}
+
+func (w *CachedWidget) Render(renderer *sdl.Renderer) {
+	w.cache.Render(renderer, []Widget{w.Widget})
+}
+
+// Tick advances the wrapped widget when it is Animated, so animations inside
+// the cache keep frames coming
+func (w *CachedWidget) Tick(dt float32) bool {
+	animated, ok := w.Widget.(Animated)
+	return ok && animated.Tick(dt)
+}
+
+// takeDirty reports changes to a cached parent (the own cache is invalidated too)
+func (w *CachedWidget) takeDirty() bool {
+	if reporter, ok := w.Widget.(dirtyReporter); ok && reporter.takeDirty() {
+		w.cache.Invalidate()
+		return true
+	}
+	return false
+}
+
+func (w *CachedWidget) Destroy() {
+	w.cache.Destroy()
+	if destroyer, ok := w.Widget.(interface{ Destroy() }); ok {
+		destroyer.Destroy()
+	}
+}
@@ -0,0 +1,78 @@
+// rendertarget.go
+package ui
+
+// Offscreen render targets: draw into a texture (at the display's pixel
+// density) and composite it later, for caching or post-processing.
+
+import (
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// RenderTarget is a texture that can be drawn into with window coordinates
+type RenderTarget struct {
+	Texture  *sdl.Texture
+	previous *sdl.Texture // Target active before Begin
+}
+
+// Ensure (re)creates the texture to cover w by h logical units.
+// Returns false if render targets are unsupported.
+func (t *RenderTarget) Ensure(renderer *sdl.Renderer, w, h float32) bool {
+	texW := int32(w*pixelDensity) + 1
+	texH := int32(h*pixelDensity) + 1
+	if t.Texture != nil {
+		var oldW, oldH float32
+		sdl.GetTextureSize(t.Texture, &oldW, &oldH)
+		if int32(oldW) == texW && int32(oldH) == texH {
+			return true
+		}
+		t.Destroy()
+	}
+	t.Texture = sdl.CreateTexture(renderer, sdl.PixelFormatRGBA8888, sdl.TextureAccessTarget, texW, texH)
+	if t.Texture == nil {
+		return false
+	}
+	sdl.SetTextureBlendMode(t.Texture, sdl.BlendModeBlend)
+	textureTracker.Track(t, func() error { // Contents are lost on device reset, owners redraw
+		t.Destroy()
+		return nil
+	})
+	return true
+}
+
+// Begin redirects drawing into the target and clears it to transparent
+func (t *RenderTarget) Begin(renderer *sdl.Renderer) {
+	FlushBatch() // Queued drawing belongs to the previous target
+	t.previous = sdl.GetRenderTarget(renderer)
+	sdl.SetRenderTarget(renderer, t.Texture)
+	sdl.SetRenderScale(renderer, pixelDensity, pixelDensity) // Scale is per render target
+	sdl.SetRenderDrawColor(renderer, 0, 0, 0, 0)
+	sdl.RenderClear(renderer)
+}
+
+// End restores the target that was active before Begin
+func (t *RenderTarget) End(renderer *sdl.Renderer) {
+	FlushBatch()
+	sdl.SetRenderTarget(renderer, t.previous)
+	t.previous = nil
+}
+
+// Draw composites the logical region src of the target (nil for all of it) onto dst,
+// faded by the inherited opacity
+func (t *RenderTarget) Draw(renderer *sdl.Renderer, src, dst *sdl.FRect) {
+	if t.Texture == nil {
+		return
+	}
+	if src != nil {
+		physical := sdl.FRect{X: src.X * pixelDensity, Y: src.Y * pixelDensity, W: src.W * pixelDensity, H: src.H * pixelDensity}
+		src = &physical
+	}
+	drawTexture(renderer, t.Texture, src, dst)
+}
+
+func (t *RenderTarget) Destroy() {
+	textureTracker.Untrack(t)
+	if t.Texture != nil {
+		sdl.DestroyTexture(t.Texture)
+		t.Texture = nil
+	}
+}
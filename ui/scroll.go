@@ -0,0 +1,172 @@
+// scroll.go
+package ui
+
+// Mouse wheel support: wheel events go to the innermost Scrollable widget
+// under the mouse, and ScrollArea shows a part of a layout too tall for the
+// space it has.
+
+import (
+	"math"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Scrollable is implemented by widgets reacting to the mouse wheel. dx and dy
+// are wheel steps, positive dy scrolls up (away from the user). Scroll returns
+// false when it didn't move (e.g. at the end), the containers then get the wheel.
+type Scrollable interface {
+	Scroll(dx, dy float32) bool
+}
+
+// WheelDelta returns the steps of a wheel event, with "natural scrolling" undone
+func WheelDelta(event sdl.Event) (dx, dy float32) {
+	wheel := event.Wheel()
+	if wheel.Direction == sdl.MouseWheelFlipped {
+		return -wheel.X, -wheel.Y
+	}
+	return wheel.X, wheel.Y
+}
+
+// ScrollAt gives a wheel event to the innermost Scrollable widget under the
+// mouse, returns true if a widget scrolled
+func ScrollAt(widgets []Widget, event sdl.Event) bool {
+	mx, my := EventPosition(event)
+	dx, dy := WheelDelta(event)
+	return scrollAt(widgets, sdl.FPoint{X: mx, Y: my}, dx, dy)
+}
+
+// Helper function scrolling the topmost widget containing point, innermost first
+func scrollAt(widgets []Widget, point sdl.FPoint, dx, dy float32) bool {
+	for n := len(widgets) - 1; n >= 0; n-- { // Last drawn is on top
+		bounds := widgets[n].GetBounds()
+		if !sdl.PointInRectFloat(point, bounds) {
+			continue
+		}
+		if scrollAt(widgetChildren(widgets[n]), point, dx, dy) {
+			return true
+		}
+		scrollable, ok := widgets[n].(Scrollable)
+		return ok && scrollable.Scroll(dx, dy)
+	}
+	return false
+}
+
+// ScrollArea shows Content within Bounds and scrolls it vertically with the wheel
+type ScrollArea struct {
+	Bounds  sdl.FRect
+	Content *Layout
+	Offset  float32 // How far the content is scrolled up
+	Step    float32 // Distance of one wheel step
+	Dirty
+}
+
+func NewScrollArea(x, y, w, h float32, content *Layout) *ScrollArea {
+	area := &ScrollArea{Bounds: sdl.FRect{X: x, Y: y, W: w, H: h}, Content: content, Step: 40}
+	area.place()
+	return area
+}
+
+// MaxOffset returns how far the content can be scrolled
+func (s *ScrollArea) MaxOffset() float32 {
+	return max(0, s.Content.GetBounds().H-s.Bounds.H)
+}
+
+// ScrollTo moves the content offset units up (clamped), returns false if it didn't move
+func (s *ScrollArea) ScrollTo(offset float32) bool {
+	offset = max(0, min(offset, s.MaxOffset()))
+	if offset == s.Offset {
+		return false
+	}
+	s.Offset = offset
+	s.place()
+	return true
+}
+
+func (s *ScrollArea) Scroll(dx, dy float32) bool {
+	return s.ScrollTo(s.Offset - dy*s.Step)
+}
+
+// Helper function positioning the content for the current offset
+func (s *ScrollArea) place() {
+	s.Content.X = s.Bounds.X
+	s.Content.Y = s.Bounds.Y - s.Offset
+	s.Content.Relayout()
+	s.MarkDirty()
+}
+
+func (s *ScrollArea) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventMouseWheel:
+		return ScrollAt([]Widget{s}, event)
+	case sdl.EventMouseButtonDown:
+		// Hidden content can't be clicked
+		if !sdl.PointInRectFloat(sdl.FPoint{X: mx, Y: my}, s.Bounds) {
+			return false
+		}
+	case sdl.EventMouseMotion:
+		// Nor hovered, widgets under a mouse outside the area see it leave
+		if !sdl.PointInRectFloat(sdl.FPoint{X: mx, Y: my}, s.Bounds) {
+			mx, my = float32(math.Inf(-1)), float32(math.Inf(-1))
+		}
+	}
+	return s.Content.Update(event, mx, my)
+}
+
+func (s *ScrollArea) Tick(dt float32) bool {
+	return s.Content.Tick(dt)
+}
+
+func (s *ScrollArea) takeDirty() bool {
+	dirty := s.Dirty.takeDirty()
+	if s.Content.takeDirty() {
+		dirty = true
+	}
+	return dirty
+}
+
+func (s *ScrollArea) Render(renderer *sdl.Renderer) {
+	var previous sdl.Rect
+	clipped := sdl.RenderClipEnabled(renderer)
+	if clipped {
+		sdl.GetRenderClipRect(renderer, &previous)
+	}
+	clip := sdl.Rect{X: int32(s.Bounds.X), Y: int32(s.Bounds.Y), W: int32(s.Bounds.W), H: int32(s.Bounds.H)}
+	FlushBatch() // Queued drawing is outside the clip
+	sdl.SetRenderClipRect(renderer, &clip)
+	s.Content.Render(renderer)
+	FlushBatch()
+	if clipped {
+		sdl.SetRenderClipRect(renderer, &previous)
+	} else {
+		sdl.SetRenderClipRect(renderer, nil)
+	}
+
+	// Scrollbar thumb while the content overflows
+	maxOffset := s.MaxOffset()
+	if maxOffset <= 0 {
+		return
+	}
+	contentH := s.Content.GetBounds().H
+	thumbH := max(s.Bounds.H*s.Bounds.H/contentH, 16)
+	thumb := sdl.FRect{
+		X: s.Bounds.X + s.Bounds.W - 6,
+		Y: s.Bounds.Y + (s.Bounds.H-thumbH)*s.Offset/maxOffset,
+		W: 4,
+		H: thumbH,
+	}
+	FillRoundedRect(renderer, thumb, 2, sdl.Color{R: 255, G: 255, B: 255, A: 120})
+}
+
+func (s *ScrollArea) GetBounds() sdl.FRect {
+	return s.Bounds
+}
+
+// SetPosition moves the area, its content moves along
+func (s *ScrollArea) SetPosition(x, y float32) {
+	s.Bounds.X, s.Bounds.Y = x, y
+	s.place()
+}
+
+func (s *ScrollArea) Destroy() {
+	s.Content.Destroy()
+}
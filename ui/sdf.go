@@ -0,0 +1,144 @@
+// sdf.go
+package ui
+
+// Text backends for drawing text at arbitrary zoom levels. The bitmap backend
+// upscales normally rendered text (blurry when zoomed in), the SDF backend
+// renders signed distance fields and thresholds them at the target size so
+// glyph edges stay crisp at any scale.
+
+import (
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// TextBackend renders a line of text scaled by the given factor (caller destroys the surface)
+type TextBackend interface {
+	RenderScaled(font *ttf.Font, text string, color sdl.Color, scale float32) *sdl.Surface
+}
+
+// Backend used by DrawTextScaled
+var textBackend TextBackend = BitmapTextBackend{}
+
+// SetTextBackend selects how scaled text is rendered (DrawTextScaled,
+// Camera2D.DrawText). Text already rendered by the previous backend is dropped.
+func SetTextBackend(backend TextBackend) {
+	textBackend = backend
+	textCache.Clear()
+}
+
+// BitmapTextBackend renders text at its normal size and stretches it
+type BitmapTextBackend struct{}
+
+func (BitmapTextBackend) RenderScaled(font *ttf.Font, text string, color sdl.Color, scale float32) *sdl.Surface {
+	surface := renderTextSurface(font, text, color)
+	if surface == nil || scale == 1 {
+		return surface
+	}
+	defer sdl.DestroySurface(surface)
+	return sdl.ScaleSurface(surface, int32(float32(surface.W)*scale), int32(float32(surface.H)*scale), sdl.ScaleModeLinear)
+}
+
+// SDFTextBackend renders text from signed distance fields
+type SDFTextBackend struct {
+	sdfFonts map[*ttf.Font]*ttf.Font // Copies of the fonts with SDF rendering enabled
+}
+
+func NewSDFTextBackend() *SDFTextBackend {
+	return &SDFTextBackend{sdfFonts: make(map[*ttf.Font]*ttf.Font)}
+}
+
+// Distance field spread used by SDL_ttf (FreeType default), in pixels
+const sdfSpread = 8
+
+// Returns the SDF copy of font, creating it on first use
+func (b *SDFTextBackend) sdfFont(font *ttf.Font) *ttf.Font {
+	if sdfFont, ok := b.sdfFonts[font]; ok {
+		return sdfFont
+	}
+	sdfFont := ttf.CopyFont(font)
+	if sdfFont != nil && !ttf.SetFontSDF(sdfFont, true) {
+		ttf.CloseFont(sdfFont)
+		sdfFont = nil
+	}
+	b.sdfFonts[font] = sdfFont // nil means SDF is unsupported, use bitmaps
+	return sdfFont
+}
+
+func (b *SDFTextBackend) RenderScaled(font *ttf.Font, text string, color sdl.Color, scale float32) *sdl.Surface {
+	sdfFont := b.sdfFont(font)
+	if sdfFont == nil {
+		return BitmapTextBackend{}.RenderScaled(font, text, color, scale)
+	}
+	ttf.SetFontSize(sdfFont, ttf.GetFontSize(font)) // Follow display scale changes
+
+	field := ttf.RenderTextBlended(sdfFont, text, 0, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+	if field == nil {
+		return nil
+	}
+	defer sdl.DestroySurface(field)
+
+	// Interpolating the distance (not the coverage) keeps edges sharp when upscaled
+	scaled := sdl.ScaleSurface(field, int32(float32(field.W)*scale), int32(float32(field.H)*scale), sdl.ScaleModeLinear)
+	if scaled == nil {
+		return nil
+	}
+	defer sdl.DestroySurface(scaled)
+	result := sdl.ConvertSurface(scaled, sdl.PixelFormatARGB8888)
+	if result == nil {
+		return nil
+	}
+
+	// Threshold the distance at the glyph edge with about one pixel of anti-aliasing
+	width := float32(0.5) / (sdfSpread * scale)
+	rgb := uint32(color.R)<<16 | uint32(color.G)<<8 | uint32(color.B)
+	sdl.LockSurface(result)
+	for y := int32(0); y < result.H; y++ {
+		row := unsafe.Slice((*uint32)(unsafe.Add(result.Pixels, uintptr(y*result.Pitch))), result.W)
+		for x := range row {
+			distance := float32(row[x]>>24) / 255
+			coverage := smoothstep(0.5-width, 0.5+width, distance)
+			row[x] = uint32(coverage*float32(color.A))<<24 | rgb
+		}
+	}
+	sdl.UnlockSurface(result)
+	return result
+}
+
+func (b *SDFTextBackend) Destroy() {
+	for font, sdfFont := range b.sdfFonts {
+		if sdfFont != nil {
+			ttf.CloseFont(sdfFont)
+		}
+		delete(b.sdfFonts, font)
+	}
+}
+
+// Helper function for smooth thresholding, 0 below edge0 and 1 above edge1
+func smoothstep(edge0, edge1, x float32) float32 {
+	t := min(max((x-edge0)/(edge1-edge0), 0), 1)
+	return t * t * (3 - 2*t)
+}
+
+// DrawTextScaled draws a line of text zoomed by scale (e.g. by a camera) with
+// the active text backend, rendered at the zoomed size instead of stretched.
+// Returns the drawn size.
+func DrawTextScaled(renderer *sdl.Renderer, font *ttf.Font, text string, x, y, scale float32, color sdl.Color) (float32, float32) {
+	if text == "" || scale <= 0 {
+		return 0, 0
+	}
+	key := newTextCacheKey(font, text, color)
+	key.scale = scale
+	texture, _, _ := textCache.Get(renderer, key, func() (*sdl.Surface, int32, int32) {
+		return textBackend.RenderScaled(font, text, color, scale), 0, 0
+	})
+	if texture == nil {
+		return 0, 0
+	}
+
+	textW, textH := textureLogicalSize(texture)
+	textRect := sdl.FRect{X: x, Y: y, W: textW, H: textH}
+	drawTexture(renderer, texture, nil, &textRect)
+	return textW, textH
+}
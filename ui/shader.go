@@ -0,0 +1,123 @@
+// shader.go
+package ui
+
+// Custom fragment shaders for 2D drawing on SDL_GPU. With app.BackendGPU the
+// renderer runs on the "gpu" render driver (Vulkan, Metal, Direct3D 12) and a
+// Shader replaces its built-in fragment shader for everything drawn between
+// Begin and End: fills, textures and text keep going through the normal
+// drawing helpers. Needs SDL 3.4 or newer.
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// ErrShadersUnsupported is returned by NewShader when the renderer doesn't run
+// on the "gpu" render driver or the SDL library is older than 3.4
+var ErrShadersUnsupported = errors.New("custom shaders need SDL 3.4 and the gpu render driver")
+
+// ShaderSource is a compiled fragment shader in the formats of the GPU APIs,
+// the one the device accepts is used (e.g. compiled with SDL_shadercross)
+type ShaderSource struct {
+	SPIRV          []byte // Vulkan
+	MSL            []byte // Metal (source code)
+	DXIL           []byte // Direct3D 12
+	EntryPoint     string // Function to run, "main" when empty (Metal sources often use "main0")
+	Samplers       uint32 // Textures the shader samples, the drawn texture is the first
+	UniformBuffers uint32 // Uniform buffers set with SetUniforms
+}
+
+// Shader is a custom fragment shader for the 2D renderer
+type Shader struct {
+	renderer *sdl.Renderer
+	device   *sdl.GPUDevice
+	shader   *sdl.GPUShader
+	state    *sdlext.GPURenderState
+}
+
+// NewShader creates a shader for a renderer on the "gpu" render driver
+func NewShader(renderer *sdl.Renderer, source ShaderSource) (*Shader, error) {
+	if !sdlext.GPURenderStatesSupported() || sdl.GetRendererName(renderer) != "gpu" {
+		return nil, ErrShadersUnsupported
+	}
+	device := (*sdl.GPUDevice)(sdl.GetPointerProperty(sdl.GetRendererProperties(renderer), sdl.PropRendererGPUDevicePointer, nil))
+	if device == nil {
+		return nil, ErrShadersUnsupported
+	}
+
+	// Pick the code in a format the device takes
+	formats := sdl.GetGPUShaderFormats(device)
+	var code []byte
+	var format sdl.GPUShaderFormat
+	switch {
+	case formats&sdl.GPUShaderFormatSPIRV != 0 && len(source.SPIRV) > 0:
+		code, format = source.SPIRV, sdl.GPUShaderFormatSPIRV
+	case formats&sdl.GPUShaderFormatMSL != 0 && len(source.MSL) > 0:
+		code, format = source.MSL, sdl.GPUShaderFormatMSL
+	case formats&sdl.GPUShaderFormatDXIL != 0 && len(source.DXIL) > 0:
+		code, format = source.DXIL, sdl.GPUShaderFormatDXIL
+	default:
+		return nil, errors.New("shader: no code in a format the GPU device accepts")
+	}
+	entryPoint := source.EntryPoint
+	if entryPoint == "" {
+		entryPoint = "main"
+	}
+
+	info := sdl.GPUShaderCreateInfo{
+		CodeSize:          uint64(len(code)),
+		Code:              &code[0],
+		Format:            format,
+		Stage:             sdl.GPUShaderStageFragment,
+		NumSamplers:       source.Samplers,
+		NumUniformBuffers: source.UniformBuffers,
+	}
+	info.SetEntryPoint(entryPoint)
+	shader := sdl.CreateGPUShader(device, &info)
+	if shader == nil {
+		return nil, errors.New(sdl.GetError())
+	}
+	state := sdlext.CreateGPURenderState(renderer, &sdlext.GPURenderStateCreateInfo{FragmentShader: shader})
+	if state == nil {
+		err := errors.New(sdl.GetError())
+		sdl.ReleaseGPUShader(device, shader)
+		return nil, err
+	}
+	return &Shader{renderer: renderer, device: device, shader: shader, state: state}, nil
+}
+
+// SetUniforms sets the contents of a uniform buffer slot, e.g. a time value
+// for an animated effect. data is copied.
+func (s *Shader) SetUniforms(slot uint32, data []byte) bool {
+	return sdlext.SetGPURenderStateFragmentUniforms(s.state, slot, data)
+}
+
+// SetUniformFloats is SetUniforms for a buffer of float values
+func (s *Shader) SetUniformFloats(slot uint32, values ...float32) bool {
+	if len(values) == 0 {
+		return false
+	}
+	return s.SetUniforms(slot, unsafe.Slice((*byte)(unsafe.Pointer(&values[0])), len(values)*4))
+}
+
+// Begin draws everything up to End with the shader
+func (s *Shader) Begin() {
+	sdlext.SetRenderGPUState(s.renderer, s.state)
+}
+
+// End goes back to the built-in shaders
+func (s *Shader) End() {
+	sdlext.SetRenderGPUState(s.renderer, nil)
+}
+
+func (s *Shader) Destroy() {
+	if s.state != nil {
+		sdlext.DestroyGPURenderState(s.state)
+		sdl.ReleaseGPUShader(s.device, s.shader)
+		s.state, s.shader = nil, nil
+	}
+}
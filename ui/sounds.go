@@ -0,0 +1,26 @@
+// sounds.go
+package ui
+
+// Feedback sounds: widgets ask for a kind of sound (a button click, a toast
+// appearing) and the application decides what plays, e.g. the App plays the
+// sounds of its theme unless muted.
+
+// UISound is a kind of feedback sound
+type UISound int
+
+const (
+	SoundClick        UISound = iota // A button was pressed
+	SoundToggle                      // Something was switched on or off
+	SoundError                       // An action failed
+	SoundNotification                // A message appeared (toast or dialog)
+)
+
+// SoundPlayer plays the feedback sounds, nil plays none (the App sets it)
+var SoundPlayer func(sound UISound)
+
+// PlaySound plays a feedback sound through SoundPlayer
+func PlaySound(sound UISound) {
+	if SoundPlayer != nil {
+		SoundPlayer(sound)
+	}
+}
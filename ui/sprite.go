@@ -0,0 +1,143 @@
+// sprite.go
+package ui
+
+// Sprites for small games next to the UI: a SpriteSheet is a texture cut
+// into frame rectangles, a Sprite draws one frame positioned, rotated,
+// flipped and tinted.
+
+import (
+	"fmt"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// SpriteSheet is a texture holding several sprite frames
+type SpriteSheet struct {
+	Texture  *sdl.Texture
+	Frames   []sdl.FRect // Frame rectangles in texture pixels
+	path     string      // File to reload after a device reset (empty if not loaded from a file)
+	renderer *sdl.Renderer
+}
+
+// NewSpriteSheet uses frames of an existing texture (the caller owns the texture)
+func NewSpriteSheet(texture *sdl.Texture, frames []sdl.FRect) *SpriteSheet {
+	return &SpriteSheet{Texture: texture, Frames: frames}
+}
+
+// LoadSpriteSheet loads an image file (BMP, PNG or JPEG) cut into a grid of frameW x frameH frames
+// (row by row). Magenta pixels of BMP files are transparent.
+func LoadSpriteSheet(renderer *sdl.Renderer, path string, frameW, frameH float32) *SpriteSheet {
+	sheet := &SpriteSheet{path: path, renderer: renderer}
+	if err := sheet.load(); err != nil {
+		panic(err)
+	}
+	sheet.Frames = gridFrames(sheet.Texture, frameW, frameH)
+	textureTracker.Track(sheet, sheet.load)
+	return sheet
+}
+
+// Load the texture from the file (also used to recreate it after a device reset)
+func (s *SpriteSheet) load() error {
+	texture := loadTexture(s.renderer, s.path)
+	if texture == nil {
+		return fmt.Errorf("%s: %s", s.path, sdl.GetError())
+	}
+	if s.Texture != nil {
+		sdl.DestroyTexture(s.Texture)
+	}
+	s.Texture = texture
+	return nil
+}
+
+// Helper function cutting a texture into a grid of frames, row by row
+func gridFrames(texture *sdl.Texture, frameW, frameH float32) []sdl.FRect {
+	var texW, texH float32
+	sdl.GetTextureSize(texture, &texW, &texH)
+	frames := []sdl.FRect{}
+	for y := float32(0); y+frameH <= texH; y += frameH {
+		for x := float32(0); x+frameW <= texW; x += frameW {
+			frames = append(frames, sdl.FRect{X: x, Y: y, W: frameW, H: frameH})
+		}
+	}
+	return frames
+}
+
+// Destroy frees the texture if the sheet loaded it
+func (s *SpriteSheet) Destroy() {
+	textureTracker.Untrack(s)
+	if s.path != "" && s.Texture != nil {
+		sdl.DestroyTexture(s.Texture)
+		s.Texture = nil
+	}
+}
+
+// Sprite draws one frame of a sprite sheet
+type Sprite struct {
+	Sheet    *SpriteSheet
+	Frame    int
+	X, Y     float32 // Position of the origin
+	W, H     float32 // Drawn size (0 uses the frame size)
+	OriginX  float32 // Rotation origin relative to the sprite's top-left corner
+	OriginY  float32
+	Rotation float64 // Degrees clockwise around the origin
+	FlipH    bool
+	FlipV    bool
+	Tint     sdl.Color // Multiplied with the texture colors (white leaves them unchanged)
+	Visible  bool
+}
+
+func NewSprite(sheet *SpriteSheet, frame int) *Sprite {
+	return &Sprite{Sheet: sheet, Frame: frame, Tint: sdl.Color{R: 255, G: 255, B: 255, A: 255}, Visible: true}
+}
+
+// Size returns the drawn size of the sprite
+func (s *Sprite) Size() (float32, float32) {
+	w, h := s.W, s.H
+	if frame := s.frameRect(); frame != nil {
+		if w == 0 {
+			w = frame.W
+		}
+		if h == 0 {
+			h = frame.H
+		}
+	}
+	return w, h
+}
+
+// Bounds returns the unrotated rectangle covered by the sprite
+func (s *Sprite) Bounds() sdl.FRect {
+	w, h := s.Size()
+	return sdl.FRect{X: s.X - s.OriginX, Y: s.Y - s.OriginY, W: w, H: h}
+}
+
+// Helper function returning the current frame rectangle (nil if out of range)
+func (s *Sprite) frameRect() *sdl.FRect {
+	if s.Sheet == nil || s.Frame < 0 || s.Frame >= len(s.Sheet.Frames) {
+		return nil
+	}
+	return &s.Sheet.Frames[s.Frame]
+}
+
+func (s *Sprite) Render(renderer *sdl.Renderer) {
+	frame := s.frameRect()
+	if !s.Visible || frame == nil || s.Sheet.Texture == nil {
+		return
+	}
+	// The texture is shared by all sprites of the sheet, set the tint for every draw
+	FlushBatch() // Rotated quads are drawn by SDL directly
+	texture := s.Sheet.Texture
+	sdl.SetTextureColorMod(texture, s.Tint.R, s.Tint.G, s.Tint.B)
+	sdl.SetTextureAlphaMod(texture, fadeAlpha(s.Tint.A))
+	sdl.SetTextureBlendMode(texture, drawBlendMode)
+
+	flip := sdl.FlipNone
+	if s.FlipH {
+		flip |= sdl.FlipHorizontal
+	}
+	if s.FlipV {
+		flip |= sdl.FlipVertical
+	}
+	dst := s.Bounds()
+	center := sdl.FPoint{X: s.OriginX, Y: s.OriginY}
+	sdl.RenderTextureRotated(renderer, texture, frame, &dst, s.Rotation, &center, flip)
+}
@@ -0,0 +1,343 @@
+// text.go
+package ui
+
+// Text drawing helpers shared by widgets
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// TextSpacing loosens or tightens text set in a font
+type TextSpacing struct {
+	Letter     float32 // Extra space between characters, in logical pixels (tracking)
+	LineHeight float32 // Line height multiplier, 0 or 1 = the font's own height
+}
+
+// Spacing configured per font (and its style variant faces)
+var fontSpacing = map[*ttf.Font]TextSpacing{}
+
+// SetFontSpacing sets letter spacing and line height for all text drawn with font.
+// OpenDyslexic readers in particular often prefer looser spacing.
+func SetFontSpacing(font *ttf.Font, spacing TextSpacing) {
+	fontSpacing[font] = spacing
+	for _, face := range fontVariants[font] {
+		fontSpacing[face] = spacing
+	}
+	textureTracker.RecreateText() // Re-render existing text with the new spacing, images stay
+	InvalidateRenderCaches()
+}
+
+// LineSkip returns the distance between the tops of consecutive lines
+func LineSkip(font *ttf.Font) float32 {
+	if multiplier := fontSpacing[font].LineHeight; multiplier > 0 {
+		return fontHeight(font) * multiplier
+	}
+	return fontHeight(font)
+}
+
+// MeasureText measures the rendered width of a string without creating a surface
+func MeasureText(font *ttf.Font, text string) float32 {
+	return measureTextPixels(font, text) / pixelDensity
+}
+
+// Same as MeasureText but in physical pixels, for composing surfaces
+func measureTextPixels(font *ttf.Font, text string) float32 {
+	if text == "" {
+		return 0
+	}
+	var w, h int32
+	ttf.GetStringSize(font, text, 0, &w, &h)
+	width := float32(w)
+	if letter := fontSpacing[font].Letter; letter != 0 {
+		width += letter * pixelDensity * float32(utf8.RuneCountInString(text)-1)
+	}
+	return width
+}
+
+// Helper function to render a line of text into a surface (caller destroys it),
+// applying the font's letter spacing
+func renderTextSurface(font *ttf.Font, text string, color sdl.Color) *sdl.Surface {
+	letter := fontSpacing[font].Letter * pixelDensity
+	if letter == 0 || utf8.RuneCountInString(text) < 2 {
+		return ttf.RenderTextBlended(font, text, 0, color)
+	}
+
+	target := sdl.CreateSurface(int32(measureTextPixels(font, text))+1, ttf.GetFontHeight(font), sdl.PixelFormatARGB8888)
+	if target == nil {
+		return nil
+	}
+	// Place each character where it would be without spacing, plus the accumulated tracking
+	index := float32(0)
+	for i, char := range text {
+		glyph := ttf.RenderTextBlended(font, string(char), 0, color)
+		if glyph == nil {
+			continue
+		}
+		var prefixW, prefixH int32
+		ttf.GetStringSize(font, text[:i], 0, &prefixW, &prefixH)
+		sdl.SetSurfaceBlendMode(glyph, sdl.BlendModeNone) // Copy as-is onto the transparent target
+		dst := sdl.Rect{X: prefixW + int32(letter*index), Y: 0, W: glyph.W, H: glyph.H}
+		sdl.BlitSurface(glyph, nil, target, &dst)
+		sdl.DestroySurface(glyph)
+		index++
+	}
+	return target
+}
+
+// DrawText draws a single line of text at the given position.
+// Returns the size of the drawn text.
+func DrawText(renderer *sdl.Renderer, font *ttf.Font, text string, x, y float32, color sdl.Color) (float32, float32) {
+	if text == "" {
+		return 0, 0
+	}
+	texture, _, _ := textCache.Get(renderer, newTextCacheKey(font, text, color), func() (*sdl.Surface, int32, int32) {
+		return renderTextSurface(font, text, color), 0, 0
+	})
+	if texture == nil {
+		return 0, 0
+	}
+
+	textW, textH := textureLogicalSize(texture)
+	textRect := sdl.FRect{X: x, Y: y, W: textW, H: textH}
+	drawTexture(renderer, texture, nil, &textRect)
+	return textW, textH
+}
+
+// Helper function returning the byte offset in text closest to the given x position
+// (relative to the start of the text)
+func textOffsetAt(font *ttf.Font, text string, x float32) int {
+	if x <= 0 {
+		return 0
+	}
+	prevW := float32(0)
+	for i, char := range text {
+		next := i + utf8.RuneLen(char)
+		w := MeasureText(font, text[:next])
+		if x < w {
+			// Pick the nearer edge of the character
+			if x-prevW < w-x {
+				return i
+			}
+			return next
+		}
+		prevW = w
+	}
+	return len(text)
+}
+
+// Helper function to draw a selection highlight behind text[start:end]
+func drawSelection(renderer *sdl.Renderer, font *ttf.Font, text string, start, end int, x, y float32) {
+	if start == end {
+		return
+	}
+	if start > end {
+		start, end = end, start
+	}
+	x1 := x + MeasureText(font, text[:start])
+	x2 := x + MeasureText(font, text[:end])
+	highlight := sdl.FRect{X: x1, Y: y, W: x2 - x1, H: fontHeight(font)}
+	FillRect(renderer, highlight, sdl.Color{R: 50, G: 100, B: 180, A: sdl.AlphaOpaque})
+}
+
+// TextEffects adds an outline and/or a drop shadow around rendered text
+type TextEffects struct {
+	OutlineColor     sdl.Color
+	OutlineThickness int32 // Outline width in pixels, 0 disables the outline
+	ShadowColor      sdl.Color
+	ShadowOffsetX    int32 // Shadow offset in pixels, 0/0 disables the shadow
+	ShadowOffsetY    int32
+}
+
+func (e TextEffects) hasShadow() bool {
+	return e.ShadowOffsetX != 0 || e.ShadowOffsetY != 0
+}
+
+// Helper function to render text with effects into one surface (caller destroys it).
+// Also returns where the text itself starts inside the surface.
+func renderTextWithEffects(font *ttf.Font, text string, color sdl.Color, effects TextEffects) (*sdl.Surface, int32, int32) {
+	fill := renderTextSurface(font, text, color)
+	if fill == nil {
+		return nil, 0, 0
+	}
+	if effects.OutlineThickness <= 0 && !effects.hasShadow() {
+		return fill, 0, 0
+	}
+	defer sdl.DestroySurface(fill)
+
+	// Outlined glyphs are rendered bigger by the thickness on every side
+	t := max(effects.OutlineThickness, 0)
+	renderOutlined := func(outlineColor sdl.Color) *sdl.Surface {
+		if t == 0 {
+			return renderTextSurface(font, text, outlineColor)
+		}
+		previous := ttf.GetFontOutline(font)
+		ttf.SetFontOutline(font, t)
+		surface := renderTextSurface(font, text, outlineColor)
+		ttf.SetFontOutline(font, previous)
+		return surface
+	}
+
+	baseW, baseH := fill.W+2*t, fill.H+2*t
+	sx, sy := effects.ShadowOffsetX, effects.ShadowOffsetY
+	minX, minY := min(0, sx), min(0, sy)
+	canvas := sdl.CreateSurface(baseW+max(sx, -sx), baseH+max(sy, -sy), sdl.PixelFormatARGB8888)
+	if canvas == nil {
+		return nil, 0, 0
+	}
+
+	// Layers back to front: shadow, outline, text
+	if effects.hasShadow() {
+		if shadow := renderOutlined(effects.ShadowColor); shadow != nil {
+			sdl.SetSurfaceBlendMode(shadow, sdl.BlendModeNone) // First layer, copy as-is
+			dst := sdl.Rect{X: sx - minX, Y: sy - minY, W: shadow.W, H: shadow.H}
+			sdl.BlitSurface(shadow, nil, canvas, &dst)
+			sdl.DestroySurface(shadow)
+		}
+	}
+	if t > 0 {
+		if outline := renderOutlined(effects.OutlineColor); outline != nil {
+			dst := sdl.Rect{X: -minX, Y: -minY, W: outline.W, H: outline.H}
+			sdl.BlitSurface(outline, nil, canvas, &dst)
+			sdl.DestroySurface(outline)
+		}
+	}
+	originX, originY := t-minX, t-minY
+	dst := sdl.Rect{X: originX, Y: originY, W: fill.W, H: fill.H}
+	sdl.BlitSurface(fill, nil, canvas, &dst)
+
+	return canvas, originX, originY
+}
+
+// Helper function to draw a line of text with outline/shadow effects.
+// x, y is where the text itself goes, effects extend around it.
+// Returns the size of the text without effects.
+func drawTextWithEffects(renderer *sdl.Renderer, font *ttf.Font, text string, x, y float32, color sdl.Color, effects TextEffects) (float32, float32) {
+	if text == "" {
+		return 0, 0
+	}
+	key := newTextCacheKey(font, text, color)
+	key.effects = effects
+	texture, originX, originY := textCache.Get(renderer, key, func() (*sdl.Surface, int32, int32) {
+		return renderTextWithEffects(font, text, color, effects)
+	})
+	if texture == nil {
+		return 0, 0
+	}
+
+	texW, texH := textureLogicalSize(texture)
+	textRect := sdl.FRect{X: x - float32(originX)/pixelDensity, Y: y - float32(originY)/pixelDensity, W: texW, H: texH}
+	drawTexture(renderer, texture, nil, &textRect)
+	return MeasureText(font, text), fontHeight(font)
+}
+
+// TruncateMode selects where text is shortened when it doesn't fit
+type TruncateMode int
+
+const (
+	TruncateNone   TruncateMode = iota // Let text overflow
+	TruncateEnd                        // "Very long nam…"
+	TruncateMiddle                     // "Very l…e label"
+	TruncateStart                      // "…ng long label"
+)
+
+const ellipsis = "…"
+
+// Helper function to shorten text with an ellipsis so it fits maxWidth.
+// Text that already fits is returned unchanged.
+func truncateText(font *ttf.Font, text string, maxWidth float32, mode TruncateMode) string {
+	if mode == TruncateNone || maxWidth <= 0 || MeasureText(font, text) <= maxWidth {
+		return text
+	}
+
+	runes := []rune(text)
+	shortened := func(keep int) string {
+		switch mode {
+		case TruncateStart:
+			return ellipsis + string(runes[len(runes)-keep:])
+		case TruncateMiddle:
+			head := (keep + 1) / 2
+			return string(runes[:head]) + ellipsis + string(runes[len(runes)-(keep-head):])
+		default:
+			return string(runes[:keep]) + ellipsis
+		}
+	}
+
+	// Binary search for the most characters that still fit
+	low, high := 0, len(runes)-1
+	for low < high {
+		mid := (low + high + 1) / 2
+		if MeasureText(font, shortened(mid)) <= maxWidth {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return shortened(low)
+}
+
+// TextAlign controls the horizontal placement of wrapped lines in a block
+type TextAlign int
+
+const (
+	AlignLeft TextAlign = iota
+	AlignCenter
+	AlignRight
+	AlignJustify // Stretch lines to the block width (except paragraph ends)
+)
+
+// TextLine is one wrapped line of a text block
+type TextLine struct {
+	Text         string
+	ParagraphEnd bool // Last line of a paragraph, never justified
+}
+
+// WrapTextLines wraps text into lines, remembering where paragraphs end
+func WrapTextLines(text string, font *ttf.Font, maxWidth float32) []TextLine {
+	lines := []TextLine{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		wrapped := WrapText(paragraph, font, maxWidth)
+		for i, line := range wrapped {
+			lines = append(lines, TextLine{Text: line, ParagraphEnd: i == len(wrapped)-1})
+		}
+	}
+	return lines
+}
+
+// AlignedX returns the x position of a line of lineWidth inside a block
+func AlignedX(x, width, lineWidth float32, align TextAlign) float32 {
+	switch align {
+	case AlignCenter:
+		return x + (width-lineWidth)/2
+	case AlignRight:
+		return x + width - lineWidth
+	}
+	return x
+}
+
+// DrawAlignedLine draws one wrapped line aligned inside [x, x+width]
+func DrawAlignedLine(renderer *sdl.Renderer, font *ttf.Font, line TextLine, x, y, width float32, align TextAlign, color sdl.Color, effects TextEffects) {
+	words := strings.Fields(line.Text)
+	if align != AlignJustify || line.ParagraphEnd || len(words) < 2 {
+		if align == AlignJustify {
+			align = AlignLeft
+		}
+		lineX := max(AlignedX(x, width, MeasureText(font, line.Text), align), x)
+		drawTextWithEffects(renderer, font, line.Text, lineX, y, color, effects)
+		return
+	}
+
+	// Spread the leftover space evenly between words
+	wordsWidth := float32(0)
+	for _, word := range words {
+		wordsWidth += MeasureText(font, word)
+	}
+	gap := (width - wordsWidth) / float32(len(words)-1)
+	for _, word := range words {
+		wordW, _ := drawTextWithEffects(renderer, font, word, x, y, color, effects)
+		x += wordW + gap
+	}
+}
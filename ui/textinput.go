@@ -0,0 +1,381 @@
+// textinput.go
+package ui
+
+// Single-line text editing widget with IME composition support
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// TextInput widget
+type TextInput struct {
+	Bounds   sdl.FRect
+	Text     string
+	Cursor   int // Caret position as a byte offset into Text
+	Anchor   int // Other end of the selection, equal to Cursor when nothing is selected
+	Focused  bool
+	OnChange func(text string)
+	Opacity  float32 // 0 (invisible) to 1 (opaque)
+
+	// IME composition (pre-edit) text, shown at the caret until committed
+	Composition       string
+	CompositionCursor int // Caret position inside the composition, in characters
+
+	font          *ttf.Font
+	renderer      *sdl.Renderer
+	window        *sdl.Window
+	lastInputArea sdl.Rect
+	lastCaretX    int32
+	selecting     bool    // Mouse drag selection in progress
+	bound         *string // Value kept in sync with Text (see BindTextInput)
+	Dirty
+}
+
+func NewTextInput(x, y, w float32, font *ttf.Font, renderer *sdl.Renderer, window *sdl.Window) *TextInput {
+	h := fontHeight(font) + 12 // Add padding
+	return &TextInput{
+		Bounds:   sdl.FRect{X: x, Y: y, W: w, H: h},
+		font:     font,
+		renderer: renderer,
+		window:   window,
+		Opacity:  1,
+	}
+}
+
+// Focus starts SDL text input so key presses produce text and IME events
+func (t *TextInput) Focus() {
+	if t.Focused {
+		return
+	}
+	t.Focused = true
+	t.lastInputArea = sdl.Rect{}
+	t.MarkDirty()
+	sdl.StartTextInput(t.window)
+}
+
+// Blur stops text input and drops any uncommitted composition
+func (t *TextInput) Blur() {
+	if !t.Focused {
+		return
+	}
+	t.Focused = false
+	t.selecting = false
+	t.Composition = ""
+	t.CompositionCursor = 0
+	t.MarkDirty()
+	sdl.StopTextInput(t.window)
+}
+
+func (t *TextInput) HasFocus() bool {
+	return t.Focused
+}
+
+// EditsText makes the FocusManager keep text input on while the input has focus
+func (t *TextInput) EditsText() bool {
+	return true
+}
+
+// SetText replaces the content and moves the caret to the end
+func (t *TextInput) SetText(text string) {
+	t.Text = text
+	t.Cursor = len(text)
+	t.Anchor = t.Cursor
+	t.changed()
+}
+
+// Selection returns the selected byte range of Text, start <= end
+func (t *TextInput) Selection() (int, int) {
+	if t.Anchor < t.Cursor {
+		return t.Anchor, t.Cursor
+	}
+	return t.Cursor, t.Anchor
+}
+
+// HasSelection reports whether any text is selected
+func (t *TextInput) HasSelection() bool {
+	return t.Anchor != t.Cursor
+}
+
+// SelectedText returns the currently selected text (empty if none)
+func (t *TextInput) SelectedText() string {
+	start, end := t.Selection()
+	return t.Text[start:end]
+}
+
+// SelectAll selects the whole content
+func (t *TextInput) SelectAll() {
+	t.Anchor = 0
+	t.Cursor = len(t.Text)
+	t.MarkDirty()
+}
+
+// Copy puts the selected text on the clipboard
+func (t *TextInput) Copy() {
+	if t.HasSelection() {
+		SetClipboardText(t.SelectedText())
+	}
+}
+
+// Cut copies the selected text to the clipboard and removes it
+func (t *TextInput) Cut() {
+	if t.HasSelection() {
+		SetClipboardText(t.SelectedText())
+		t.deleteSelection()
+	}
+}
+
+// Paste replaces the selection with the clipboard text.
+// Line breaks are turned into spaces since the input is single-line.
+func (t *TextInput) Paste() {
+	if !HasClipboardText() {
+		return
+	}
+	text := strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(GetClipboardText())
+	t.insert(text)
+}
+
+// Removes the selected text, returns false if nothing was selected
+func (t *TextInput) deleteSelection() bool {
+	if !t.HasSelection() {
+		return false
+	}
+	start, end := t.Selection()
+	t.Text = t.Text[:start] + t.Text[end:]
+	t.Cursor = start
+	t.Anchor = start
+	t.changed()
+	return true
+}
+
+// Moves the caret, extending the selection when extend is true
+func (t *TextInput) moveCursor(offset int, extend bool) {
+	t.Cursor = offset
+	if !extend {
+		t.Anchor = offset
+	}
+}
+
+func (t *TextInput) changed() {
+	t.MarkDirty()
+	if t.bound != nil {
+		*t.bound = t.Text
+	}
+	if t.OnChange != nil {
+		t.OnChange(t.Text)
+	}
+}
+
+func (t *TextInput) insert(text string) {
+	t.deleteSelection()
+	t.Text = t.Text[:t.Cursor] + text + t.Text[t.Cursor:]
+	t.Cursor += len(text)
+	t.Anchor = t.Cursor
+	t.changed()
+}
+
+func (t *TextInput) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventMouseButtonDown:
+		if mx >= t.Bounds.X && mx <= t.Bounds.X+t.Bounds.W &&
+			my >= t.Bounds.Y && my <= t.Bounds.Y+t.Bounds.H {
+			t.Focus()
+			// Place the caret under the mouse, shift-click extends the selection
+			extend := sdl.GetModState()&sdl.KeymodShift != 0
+			t.moveCursor(textOffsetAt(t.font, t.Text, mx-t.Bounds.X-6), extend)
+			t.selecting = true
+			t.MarkDirty()
+			return true
+		}
+		t.Blur()
+		return false
+	case sdl.EventMouseMotion:
+		if t.selecting {
+			t.moveCursor(textOffsetAt(t.font, t.Text, mx-t.Bounds.X-6), true)
+			t.MarkDirty()
+			return true
+		}
+		return false
+	case sdl.EventMouseButtonUp:
+		if t.selecting {
+			t.selecting = false
+			return true
+		}
+		return false
+	}
+
+	if !t.Focused {
+		return false
+	}
+
+	switch event.Type() {
+	case sdl.EventTextEditing:
+		// IME is composing, show the pre-edit text without committing it
+		edit := event.Edit()
+		t.Composition = edit.Text()
+		t.CompositionCursor = int(edit.Start)
+		t.MarkDirty()
+		return true
+	case sdl.EventTextInput:
+		// Composition (or a plain key press) was committed
+		input := event.Text()
+		t.Composition = ""
+		t.CompositionCursor = 0
+		t.insert(input.Text())
+		return true
+	case sdl.EventKeyDown:
+		// While composing, editing keys belong to the input method
+		if t.Composition != "" {
+			return true
+		}
+		if scancode := event.Key().Scancode; scancode >= sdl.ScancodeF1 && scancode <= sdl.ScancodeF12 {
+			return false // Function keys never edit text, leave them to the app
+		}
+		extend := event.Key().Mod&sdl.KeymodShift != 0
+		switch event.Key().Scancode {
+		case sdl.ScancodeBackspace:
+			if !t.deleteSelection() && t.Cursor > 0 {
+				_, size := utf8.DecodeLastRuneInString(t.Text[:t.Cursor])
+				t.Text = t.Text[:t.Cursor-size] + t.Text[t.Cursor:]
+				t.Cursor -= size
+				t.Anchor = t.Cursor
+				t.changed()
+			}
+		case sdl.ScancodeDelete:
+			if !t.deleteSelection() && t.Cursor < len(t.Text) {
+				_, size := utf8.DecodeRuneInString(t.Text[t.Cursor:])
+				t.Text = t.Text[:t.Cursor] + t.Text[t.Cursor+size:]
+				t.changed()
+			}
+		case sdl.ScancodeLeft:
+			if t.HasSelection() && !extend {
+				start, _ := t.Selection()
+				t.moveCursor(start, false) // Collapse to the left edge
+			} else if t.Cursor > 0 {
+				_, size := utf8.DecodeLastRuneInString(t.Text[:t.Cursor])
+				t.moveCursor(t.Cursor-size, extend)
+			}
+		case sdl.ScancodeRight:
+			if t.HasSelection() && !extend {
+				_, end := t.Selection()
+				t.moveCursor(end, false) // Collapse to the right edge
+			} else if t.Cursor < len(t.Text) {
+				_, size := utf8.DecodeRuneInString(t.Text[t.Cursor:])
+				t.moveCursor(t.Cursor+size, extend)
+			}
+		case sdl.ScancodeHome:
+			t.moveCursor(0, extend)
+		case sdl.ScancodeEnd:
+			t.moveCursor(len(t.Text), extend)
+		case sdl.ScancodeA:
+			if event.Key().Mod&sdl.KeymodCtrl != 0 {
+				t.SelectAll()
+			}
+		case sdl.ScancodeC:
+			if event.Key().Mod&sdl.KeymodCtrl != 0 {
+				t.Copy()
+			}
+		case sdl.ScancodeX:
+			if event.Key().Mod&sdl.KeymodCtrl != 0 {
+				t.Cut()
+			}
+		case sdl.ScancodeV:
+			if event.Key().Mod&sdl.KeymodCtrl != 0 {
+				t.Paste()
+			}
+		case sdl.ScancodeEscape:
+			t.Blur()
+		}
+		t.MarkDirty() // Caret may have moved
+		// Swallow all other keys so they don't reach the app while typing
+		return true
+	}
+	return false
+}
+
+func (t *TextInput) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(t.Opacity)()
+
+	// Draw input background
+	background := sdl.Color{R: 60, G: 60, B: 60, A: sdl.AlphaOpaque}
+	if t.Focused {
+		background = sdl.Color{R: 40, G: 40, B: 40, A: sdl.AlphaOpaque}
+	}
+	FillRect(renderer, t.Bounds, background)
+	SetDrawColor(renderer, 120, 120, 120, sdl.AlphaOpaque)
+	sdl.RenderRect(renderer, &t.Bounds)
+
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	textX := t.Bounds.X + 6
+	textY := t.Bounds.Y + 6
+	lineH := fontHeight(t.font)
+
+	if t.Focused && t.Composition == "" {
+		start, end := t.Selection()
+		drawSelection(renderer, t.font, t.Text, start, end, textX, textY)
+	}
+
+	// Text before the caret, then the composition, then the rest
+	before := t.Text[:t.Cursor]
+	after := t.Text[t.Cursor:]
+	DrawText(renderer, t.font, before, textX, textY, white)
+	caretX := textX + MeasureText(t.font, before)
+
+	if t.Composition != "" {
+		compW := MeasureText(t.font, t.Composition)
+		DrawText(renderer, t.font, t.Composition, caretX, textY, sdl.Color{R: 255, G: 230, B: 150, A: 255})
+
+		// Underline marks the text as not yet committed
+		SetDrawColor(renderer, 255, 230, 150, sdl.AlphaOpaque)
+		sdl.RenderLine(renderer, caretX, textY+lineH, caretX+compW, textY+lineH)
+
+		DrawText(renderer, t.font, after, caretX+compW, textY, white)
+		caretX += MeasureText(t.font, prefixRunes(t.Composition, t.CompositionCursor))
+	} else {
+		DrawText(renderer, t.font, after, caretX, textY, white)
+	}
+
+	if t.Focused {
+		SetDrawColor(renderer, 255, 255, 255, sdl.AlphaOpaque)
+		sdl.RenderLine(renderer, caretX, textY, caretX, textY+lineH)
+		t.updateInputArea(caretX)
+	}
+}
+
+// Tell SDL where the caret is so the IME candidate window opens next to it
+func (t *TextInput) updateInputArea(caretX float32) {
+	area := sdl.Rect{X: int32(t.Bounds.X), Y: int32(t.Bounds.Y), W: int32(t.Bounds.W), H: int32(t.Bounds.H)}
+	cursor := int32(caretX - t.Bounds.X)
+	if area == t.lastInputArea && cursor == t.lastCaretX {
+		return
+	}
+	t.lastInputArea = area
+	t.lastCaretX = cursor
+	sdl.SetTextInputArea(t.window, &area, cursor)
+}
+
+func (t *TextInput) GetBounds() sdl.FRect {
+	return t.Bounds
+}
+
+func (t *TextInput) SetPosition(x, y float32) {
+	t.Bounds.X, t.Bounds.Y = x, y
+}
+
+func (t *TextInput) Destroy() {
+	t.Blur()
+}
+
+// Helper function returning the first n characters of a string
+func prefixRunes(text string, n int) string {
+	for i := range text {
+		if n == 0 {
+			return text[:i]
+		}
+		n--
+	}
+	return text
+}
@@ -0,0 +1,120 @@
+// texturecache.go
+package ui
+
+// LRU cache of text textures, so text drawn every frame (bottom text, alert
+// dialog) is rendered once and reused until its text, font or color changes.
+
+import (
+	"container/list"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// Identifies a rendered piece of text
+type textCacheKey struct {
+	font    *ttf.Font
+	size    float32 // Font size and style are part of the key, fonts change in place
+	style   ttf.FontStyleFlags
+	spacing TextSpacing
+	text    string
+	color   sdl.Color
+	effects TextEffects
+	scale   float32
+	density float32
+}
+
+type textCacheEntry struct {
+	key     textCacheKey
+	texture *sdl.Texture
+	originX int32 // Text origin inside the texture (outline/shadow padding), in pixels
+	originY int32
+}
+
+// TextTextureCache keeps the most recently used text textures
+type TextTextureCache struct {
+	Capacity int
+	entries  map[textCacheKey]*list.Element
+	order    *list.List // Front is most recently used
+}
+
+func NewTextTextureCache(capacity int) *TextTextureCache {
+	cache := &TextTextureCache{
+		Capacity: capacity,
+		entries:  make(map[textCacheKey]*list.Element),
+		order:    list.New(),
+	}
+	textureTracker.TrackText(cache, cache.Clear) // Re-rendered lazily after a device reset
+	return cache
+}
+
+// Cache shared by the text drawing helpers
+var textCache = NewTextTextureCache(256)
+
+// ClearTextCache frees the cached text textures, call it before destroying the renderer
+func ClearTextCache() {
+	textCache.Clear()
+}
+
+// Helper function building the cache key for text drawn with font
+func newTextCacheKey(font *ttf.Font, text string, color sdl.Color) textCacheKey {
+	return textCacheKey{
+		font:    font,
+		size:    ttf.GetFontSize(font),
+		style:   ttf.GetFontStyle(font),
+		spacing: fontSpacing[font],
+		text:    text,
+		color:   color,
+		scale:   1,
+		density: pixelDensity,
+	}
+}
+
+// Get returns the cached texture for key, calling render to create it on a miss.
+// render returns a surface (which Get destroys) and the text origin inside it.
+func (c *TextTextureCache) Get(renderer *sdl.Renderer, key textCacheKey, render func() (*sdl.Surface, int32, int32)) (*sdl.Texture, int32, int32) {
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		entry := element.Value.(*textCacheEntry)
+		return entry.texture, entry.originX, entry.originY
+	}
+
+	surface, originX, originY := render()
+	if surface == nil {
+		return nil, 0, 0
+	}
+	texture := sdl.CreateTextureFromSurface(renderer, surface)
+	sdl.DestroySurface(surface)
+	if texture == nil {
+		return nil, 0, 0
+	}
+
+	entry := &textCacheEntry{key: key, texture: texture, originX: originX, originY: originY}
+	c.entries[key] = c.order.PushFront(entry)
+
+	// Evict least recently used textures
+	for c.order.Len() > c.Capacity {
+		oldest := c.order.Back()
+		c.remove(oldest)
+	}
+	return texture, originX, originY
+}
+
+func (c *TextTextureCache) remove(element *list.Element) {
+	entry := element.Value.(*textCacheEntry)
+	sdl.DestroyTexture(entry.texture)
+	delete(c.entries, entry.key)
+	c.order.Remove(element)
+}
+
+// Clear destroys all cached textures
+func (c *TextTextureCache) Clear() {
+	for c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of cached textures
+func (c *TextTextureCache) Len() int {
+	return c.order.Len()
+}
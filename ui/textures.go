@@ -0,0 +1,73 @@
+// textures.go
+package ui
+
+// Texture tracking: every toolkit-created texture registers how to rebuild
+// itself, so textures lost on a render device reset (GPU reset, leaving
+// exclusive fullscreen) can be recreated instead of drawing black rectangles.
+// Rendered text is tracked apart from textures loaded from files, so a font
+// change (display scale, spacing) re-renders text without touching the disk.
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// TextureTracker remembers how to recreate textures from their source data
+type TextureTracker struct {
+	sources map[any]func() error // Textures loaded from files or pixels
+	text    map[any]func()       // Rendered text
+}
+
+// Registry used by all widgets and caches
+var textureTracker = &TextureTracker{sources: make(map[any]func() error), text: make(map[any]func())}
+
+// Track registers a recreate callback for the textures owned by owner.
+// Tracking the same owner again replaces its callback.
+func (t *TextureTracker) Track(owner any, recreate func() error) {
+	t.sources[owner] = recreate
+}
+
+// TrackText registers a callback re-rendering the text textures owned by
+// owner, run on device resets and when fonts change
+func (t *TextureTracker) TrackText(owner any, rerender func()) {
+	t.text[owner] = rerender
+}
+
+// Untrack forgets owner, call it when the owner destroys its textures
+func (t *TextureTracker) Untrack(owner any) {
+	delete(t.sources, owner)
+	delete(t.text, owner)
+}
+
+// RecreateAll rebuilds every tracked texture from its source data. Textures
+// that fail (e.g. a file was moved) keep their old contents, the errors are
+// returned together.
+func (t *TextureTracker) RecreateAll() error {
+	var errs []error
+	for _, recreate := range t.sources {
+		if err := recreate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	t.RecreateText()
+	return errors.Join(errs...)
+}
+
+// RecreateText re-renders only the text textures, e.g. after a font size change
+func (t *TextureTracker) RecreateText() {
+	for _, rerender := range t.text {
+		rerender()
+	}
+}
+
+// Count returns the number of tracked texture owners
+func (t *TextureTracker) Count() int {
+	return len(t.sources) + len(t.text)
+}
+
+// RecreateTextures rebuilds every tracked texture, call it on sdl.EventRenderDeviceReset
+func RecreateTextures() {
+	if err := textureTracker.RecreateAll(); err != nil {
+		slog.Warn("textures not recreated", "error", err)
+	}
+}
@@ -0,0 +1,399 @@
+// tilemap.go
+package ui
+
+// Tile maps made with the Tiled editor (JSON format, .tmj/.json), rendered
+// layer by layer with only the tiles inside the view drawn. Tile queries
+// support simple collision checks.
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+)
+
+// Tiled stores tile flips in the top bits of a tile id
+const (
+	tileFlipHorizontal = 0x80000000
+	tileFlipVertical   = 0x40000000
+	tileFlipDiagonal   = 0x20000000
+	tileIDMask         = 0x1fffffff
+)
+
+// TileLayer is a grid of global tile ids (0 is an empty cell)
+type TileLayer struct {
+	Name    string
+	Width   int
+	Height  int
+	Data    []uint32 // Row by row, may include flip bits
+	Visible bool
+	Opacity float32
+}
+
+// A tileset image and how tile ids map into it
+type tileset struct {
+	firstGID   uint32
+	texture    *sdl.Texture
+	imagePath  string
+	columns    int
+	tileWidth  float32
+	tileHeight float32
+	margin     float32
+	spacing    float32
+	properties map[uint32]map[string]any // Per local tile id
+}
+
+// Tilemap is a map loaded from Tiled
+type Tilemap struct {
+	Width      int // In tiles
+	Height     int
+	TileWidth  float32
+	TileHeight float32
+	Layers     []TileLayer
+	tilesets   []*tileset
+	renderer   *sdl.Renderer
+	batch      *RenderBatch
+}
+
+// JSON structure of Tiled maps and tilesets (only the fields used here)
+type tiledProperty struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+type tiledTileset struct {
+	FirstGID   uint32  `json:"firstgid"`
+	Source     string  `json:"source"`
+	Image      string  `json:"image"`
+	Columns    int     `json:"columns"`
+	TileCount  int     `json:"tilecount"`
+	TileWidth  float32 `json:"tilewidth"`
+	TileHeight float32 `json:"tileheight"`
+	Margin     float32 `json:"margin"`
+	Spacing    float32 `json:"spacing"`
+	Tiles      []struct {
+		ID         uint32          `json:"id"`
+		Properties []tiledProperty `json:"properties"`
+	} `json:"tiles"`
+}
+
+type tiledLayer struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Data        json.RawMessage `json:"data"` // Array of ids, or a base64 string
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Visible     bool            `json:"visible"`
+	Opacity     float32         `json:"opacity"`
+}
+
+type tiledMap struct {
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	TileWidth  float32        `json:"tilewidth"`
+	TileHeight float32        `json:"tileheight"`
+	Infinite   bool           `json:"infinite"`
+	Layers     []tiledLayer   `json:"layers"`
+	Tilesets   []tiledTileset `json:"tilesets"`
+}
+
+// LoadTilemap loads a Tiled JSON map and its tileset images (paths are relative to the map)
+func LoadTilemap(renderer *sdl.Renderer, path string) (*Tilemap, error) {
+	var source tiledMap
+	if err := readJSON(path, &source); err != nil {
+		return nil, err
+	}
+	if source.Infinite {
+		return nil, errors.New("tilemap: infinite maps are not supported")
+	}
+
+	m := &Tilemap{
+		Width:      source.Width,
+		Height:     source.Height,
+		TileWidth:  source.TileWidth,
+		TileHeight: source.TileHeight,
+		renderer:   renderer,
+	}
+	dir := filepath.Dir(path)
+	for _, ts := range source.Tilesets {
+		set, err := loadTileset(ts, dir)
+		if err != nil {
+			m.Destroy()
+			return nil, err
+		}
+		set.texture = loadTexture(renderer, set.imagePath)
+		if set.texture == nil {
+			m.Destroy()
+			return nil, fmt.Errorf("tilemap: %s: %s", set.imagePath, sdl.GetError())
+		}
+		m.tilesets = append(m.tilesets, set)
+	}
+	for _, layer := range source.Layers {
+		if layer.Type != "tilelayer" {
+			continue // Object and image layers are not rendered
+		}
+		data, err := decodeTileData(layer)
+		if err != nil {
+			m.Destroy()
+			return nil, fmt.Errorf("tilemap: layer %q: %w", layer.Name, err)
+		}
+		if len(data) != layer.Width*layer.Height {
+			m.Destroy()
+			return nil, fmt.Errorf("tilemap: layer %q: %d tiles for %dx%d", layer.Name, len(data), layer.Width, layer.Height)
+		}
+		m.Layers = append(m.Layers, TileLayer{
+			Name:    layer.Name,
+			Width:   layer.Width,
+			Height:  layer.Height,
+			Data:    data,
+			Visible: layer.Visible,
+			Opacity: layer.Opacity,
+		})
+	}
+	textureTracker.Track(m, m.reloadTextures)
+	return m, nil
+}
+
+// Helper function to decode a JSON file
+func readJSON(path string, value any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Helper function resolving an embedded or external (.tsj) tileset
+func loadTileset(ts tiledTileset, dir string) (*tileset, error) {
+	firstGID := ts.FirstGID
+	if ts.Source != "" {
+		sourcePath := filepath.Join(dir, ts.Source)
+		if err := readJSON(sourcePath, &ts); err != nil {
+			return nil, fmt.Errorf("tilemap: tileset %s: %w", ts.Source, err)
+		}
+		dir = filepath.Dir(sourcePath) // Image path is relative to the tileset file
+	}
+	if ts.Image == "" {
+		return nil, errors.New("tilemap: image collection tilesets are not supported")
+	}
+	set := &tileset{
+		firstGID:   firstGID,
+		imagePath:  filepath.Join(dir, ts.Image),
+		columns:    max(ts.Columns, 1),
+		tileWidth:  ts.TileWidth,
+		tileHeight: ts.TileHeight,
+		margin:     ts.Margin,
+		spacing:    ts.Spacing,
+		properties: make(map[uint32]map[string]any),
+	}
+	for _, tile := range ts.Tiles {
+		properties := make(map[string]any)
+		for _, property := range tile.Properties {
+			properties[property.Name] = property.Value
+		}
+		set.properties[tile.ID] = properties
+	}
+	return set, nil
+}
+
+// Helper function decoding layer data stored as an id array or uncompressed base64
+func decodeTileData(layer tiledLayer) ([]uint32, error) {
+	var data []uint32
+	if layer.Encoding != "base64" {
+		if err := json.Unmarshal(layer.Data, &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	if layer.Compression != "" {
+		return nil, fmt.Errorf("%s compression is not supported", layer.Compression)
+	}
+	var encoded string
+	if err := json.Unmarshal(layer.Data, &encoded); err != nil {
+		return nil, err
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	data = make([]uint32, len(raw)/4)
+	for i := range data {
+		data[i] = binary.LittleEndian.Uint32(raw[i*4:])
+	}
+	return data, nil
+}
+
+// Recreate the tileset textures after a device reset
+func (m *Tilemap) reloadTextures() error {
+	for _, set := range m.tilesets {
+		texture := loadTexture(m.renderer, set.imagePath)
+		if texture == nil {
+			return fmt.Errorf("tilemap: %s: %s", set.imagePath, sdl.GetError())
+		}
+		if set.texture != nil {
+			sdl.DestroyTexture(set.texture)
+		}
+		set.texture = texture
+	}
+	return nil
+}
+
+// Helper function finding the tileset of a global tile id
+func (m *Tilemap) tilesetFor(gid uint32) *tileset {
+	for i := len(m.tilesets) - 1; i >= 0; i-- {
+		if gid >= m.tilesets[i].firstGID {
+			return m.tilesets[i]
+		}
+	}
+	return nil
+}
+
+// Render draws the world region view (in map pixels) onto the screen origin,
+// scaled by scale. Only tiles inside view are drawn.
+func (m *Tilemap) Render(renderer *sdl.Renderer, view sdl.FRect, scale float32) {
+	if m.TileWidth <= 0 || m.TileHeight <= 0 || scale <= 0 {
+		return
+	}
+	// Visible tile range
+	firstX := max(0, int(math.Floor(float64(view.X/m.TileWidth))))
+	firstY := max(0, int(math.Floor(float64(view.Y/m.TileHeight))))
+	lastX := min(m.Width-1, int(math.Ceil(float64((view.X+view.W)/m.TileWidth))))
+	lastY := min(m.Height-1, int(math.Ceil(float64((view.Y+view.H)/m.TileHeight))))
+
+	// Tiles sharing a tileset are drawn in one call, after what the shared batch queued
+	FlushBatch()
+	if m.batch == nil {
+		m.batch = NewRenderBatch(renderer)
+	}
+	for _, layer := range m.Layers {
+		if !layer.Visible {
+			continue
+		}
+		color := sdl.Color{R: 255, G: 255, B: 255, A: uint8(layer.Opacity * 255)}
+		for ty := firstY; ty <= min(lastY, layer.Height-1); ty++ {
+			for tx := firstX; tx <= min(lastX, layer.Width-1); tx++ {
+				gid := layer.Data[ty*layer.Width+tx]
+				if gid&tileIDMask == 0 {
+					continue
+				}
+				x := (float32(tx)*m.TileWidth - view.X) * scale
+				y := (float32(ty)*m.TileHeight - view.Y) * scale
+				m.drawTile(gid, x, y, scale, color)
+			}
+		}
+	}
+	m.batch.Flush()
+}
+
+// Helper function queueing one tile with its flips at screen position x, y
+func (m *Tilemap) drawTile(gid uint32, x, y, scale float32, color sdl.Color) {
+	set := m.tilesetFor(gid & tileIDMask)
+	if set == nil || set.texture == nil {
+		return
+	}
+	local := int(gid&tileIDMask - set.firstGID)
+	src := sdl.FRect{
+		X: set.margin + float32(local%set.columns)*(set.tileWidth+set.spacing),
+		Y: set.margin + float32(local/set.columns)*(set.tileHeight+set.spacing),
+		W: set.tileWidth,
+		H: set.tileHeight,
+	}
+	// Tiles taller than the grid are anchored at the bottom of their cell
+	dst := sdl.FRect{X: x, Y: y + (m.TileHeight-set.tileHeight)*scale, W: set.tileWidth * scale, H: set.tileHeight * scale}
+
+	// Flips swap texture coordinates, Tiled applies the diagonal flip first
+	uvs := textureUVs(set.texture, &src) // Top-left, top-right, bottom-right, bottom-left
+	if gid&tileFlipDiagonal != 0 {
+		uvs[1], uvs[3] = uvs[3], uvs[1]
+	}
+	if gid&tileFlipHorizontal != 0 {
+		uvs[0], uvs[1], uvs[2], uvs[3] = uvs[1], uvs[0], uvs[3], uvs[2]
+	}
+	if gid&tileFlipVertical != 0 {
+		uvs[0], uvs[1], uvs[2], uvs[3] = uvs[3], uvs[2], uvs[1], uvs[0]
+	}
+	m.batch.AddQuad(set.texture, rectCorners(dst), uvs, color)
+}
+
+// LayerIndex returns the index of the named layer, or -1
+func (m *Tilemap) LayerIndex(name string) int {
+	for i, layer := range m.Layers {
+		if layer.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// TileAt returns the global tile id (without flip bits) at a tile position, 0 if empty or outside
+func (m *Tilemap) TileAt(layer, tx, ty int) uint32 {
+	if layer < 0 || layer >= len(m.Layers) {
+		return 0
+	}
+	l := m.Layers[layer]
+	if tx < 0 || ty < 0 || tx >= l.Width || ty >= l.Height {
+		return 0
+	}
+	return l.Data[ty*l.Width+tx] & tileIDMask
+}
+
+// TileAtPoint returns the tile id under a point in map pixels
+func (m *Tilemap) TileAtPoint(layer int, x, y float32) uint32 {
+	if x < 0 || y < 0 {
+		return 0
+	}
+	return m.TileAt(layer, int(x/m.TileWidth), int(y/m.TileHeight))
+}
+
+// TileProperty returns a custom property set on a tile in Tiled
+func (m *Tilemap) TileProperty(gid uint32, name string) (any, bool) {
+	gid &= tileIDMask
+	set := m.tilesetFor(gid)
+	if set == nil {
+		return nil, false
+	}
+	value, ok := set.properties[gid-set.firstGID][name]
+	return value, ok
+}
+
+// CollidesRect reports whether any non-empty tile of the layer overlaps rect (map pixels)
+func (m *Tilemap) CollidesRect(layer int, rect sdl.FRect) bool {
+	firstX := int(math.Floor(float64(rect.X / m.TileWidth)))
+	firstY := int(math.Floor(float64(rect.Y / m.TileHeight)))
+	lastX := int(math.Ceil(float64((rect.X+rect.W)/m.TileWidth))) - 1
+	lastY := int(math.Ceil(float64((rect.Y+rect.H)/m.TileHeight))) - 1
+	for ty := firstY; ty <= lastY; ty++ {
+		for tx := firstX; tx <= lastX; tx++ {
+			if m.TileAt(layer, tx, ty) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PixelSize returns the map size in map pixels
+func (m *Tilemap) PixelSize() (float32, float32) {
+	return float32(m.Width) * m.TileWidth, float32(m.Height) * m.TileHeight
+}
+
+func (m *Tilemap) Destroy() {
+	textureTracker.Untrack(m)
+	for _, set := range m.tilesets {
+		if set.texture != nil {
+			sdl.DestroyTexture(set.texture)
+			set.texture = nil
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// titlebar.go
+package ui
+
+// Title bar drawn by the application for borderless windows (custom window
+// chrome): the title with minimize, maximize and close buttons. HitTest tells
+// the system which parts move the window (see app.App.SetHitTest).
+
+import (
+	"encoding/binary"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+
+	"arkenidar.com/purego-sdl3/internal/sdlext"
+)
+
+// TitleBar widget
+type TitleBar struct {
+	Bounds     sdl.FRect
+	Title      *Label
+	Minimize   *Button
+	Maximize   *Button // Restores a maximized window
+	Close      *Button // Sends sdl.EventQuit unless its OnClick is replaced
+	Background sdl.Color
+	window     *sdl.Window
+}
+
+// NewTitleBar creates a title bar across the top of window, height logical units high
+func NewTitleBar(window *sdl.Window, title string, height float32, font *ttf.Font, renderer *sdl.Renderer) *TitleBar {
+	bar := &TitleBar{
+		Bounds:     sdl.FRect{H: height},
+		Title:      NewLabel(0, 0, title, font, renderer),
+		Background: sdl.Color{R: 40, G: 40, B: 45, A: 255},
+		window:     window,
+	}
+	bar.Minimize = NewButton(0, 0, height*1.4, height, "_", font, renderer, func() { sdlext.MinimizeWindow(window) })
+	bar.Maximize = NewButton(0, 0, height*1.4, height, "[ ]", font, renderer, bar.toggleMaximized)
+	bar.Close = NewButton(0, 0, height*1.4, height, "X", font, renderer, func() {
+		var event sdl.Event
+		binary.NativeEndian.PutUint32(event[:4], uint32(sdl.EventQuit))
+		sdl.PushEvent(&event)
+	})
+	bar.Resize(0)
+	return bar
+}
+
+// Resize spreads the bar over width, call it when the window is resized
+func (t *TitleBar) Resize(width float32) {
+	if width <= 0 {
+		var w, h int32
+		sdl.GetWindowSize(t.window, &w, &h)
+		width = float32(w)
+	}
+	t.Bounds.W = width
+	x := t.Bounds.X + width
+	for _, button := range t.buttons() {
+		x -= button.Bounds.W
+		button.Bounds.X, button.Bounds.Y = x, t.Bounds.Y
+	}
+	t.Title.Bounds.X = t.Bounds.X + 10
+	t.Title.Bounds.Y = t.Bounds.Y + (t.Bounds.H-t.Title.Bounds.H)/2
+	t.Title.SetMaxWidth(max(x-t.Title.Bounds.X-10, 1))
+}
+
+// Helper function returning the buttons from right to left
+func (t *TitleBar) buttons() []*Button {
+	return []*Button{t.Close, t.Maximize, t.Minimize}
+}
+
+// Helper function maximizing the window, or restoring it when it is maximized
+func (t *TitleBar) toggleMaximized() {
+	if sdlext.GetWindowFlags(t.window)&sdl.WindowMaximized != 0 {
+		sdl.RestoreWindow(t.window)
+	} else {
+		sdlext.MaximizeWindow(t.window)
+	}
+}
+
+// HitTest returns sdl.HitTestDraggable where dragging moves the window (the
+// bar outside its buttons) and sdl.HitTestNormal elsewhere
+func (t *TitleBar) HitTest(x, y float32) sdl.HitTestResult {
+	point := sdl.FPoint{X: x, Y: y}
+	if !sdl.PointInRectFloat(point, t.Bounds) {
+		return sdl.HitTestNormal
+	}
+	for _, button := range t.buttons() {
+		if sdl.PointInRectFloat(point, button.Bounds) {
+			return sdl.HitTestNormal
+		}
+	}
+	return sdl.HitTestDraggable
+}
+
+func (t *TitleBar) Update(event sdl.Event, mx, my float32) bool {
+	handled := false
+	for _, button := range t.buttons() {
+		// Every button sees motion to update its hover state
+		if button.Update(event, mx, my) {
+			handled = true
+		}
+	}
+	return handled
+}
+
+func (t *TitleBar) Render(renderer *sdl.Renderer) {
+	FillRect(renderer, t.Bounds, t.Background)
+	t.Title.Render(renderer)
+	for _, button := range t.buttons() {
+		button.Render(renderer)
+	}
+}
+
+func (t *TitleBar) GetBounds() sdl.FRect {
+	return t.Bounds
+}
+
+// SetPosition moves the bar with its buttons
+func (t *TitleBar) SetPosition(x, y float32) {
+	t.Bounds.X, t.Bounds.Y = x, y
+	t.Resize(t.Bounds.W)
+}
+
+func (t *TitleBar) Destroy() {
+	t.Title.Destroy()
+	for _, button := range t.buttons() {
+		button.Destroy()
+	}
+}
@@ -0,0 +1,131 @@
+// toast.go
+package ui
+
+// Toasts: short messages stacked in the bottom-right corner of the window
+// that fade out by themselves, e.g. "Saved" or the notifications of
+// background work (see app.App.Notify).
+
+import (
+	"strings"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// Seconds a toast takes to fade in or out
+const toastFadeTime = 0.2
+
+// One message shown by a Toaster
+type toast struct {
+	title string
+	lines []string
+	left  float32 // Seconds until it starts fading out
+	fade  *Fade
+}
+
+// Toaster shows toasts over the other widgets, call Tick every frame (e.g.
+// with app.App.OnTick)
+type Toaster struct {
+	Bounds     sdl.FRect // Area the toasts are placed in, usually the whole window
+	Duration   float32   // Seconds a toast stays visible
+	Background sdl.Color
+	Color      sdl.Color
+	font       *ttf.Font
+	toasts     []*toast // Oldest first
+}
+
+func NewToaster(font *ttf.Font) *Toaster {
+	return &Toaster{
+		Duration:   4,
+		Background: sdl.Color{R: 30, G: 30, B: 35, A: 230},
+		Color:      sdl.Color{R: 240, G: 240, B: 240, A: 255},
+		font:       font,
+	}
+}
+
+// Show adds a toast with a title line and a body (may have several lines)
+func (t *Toaster) Show(title, body string) {
+	var lines []string
+	if body != "" {
+		lines = strings.Split(body, "\n")
+	}
+	item := &toast{title: title, lines: lines, left: t.Duration, fade: NewFade(toastFadeTime, false)}
+	item.fade.SetVisible(true)
+	t.toasts = append(t.toasts, item)
+	PlaySound(SoundNotification)
+}
+
+// Tick counts down and fades the toasts, returns true while any is shown
+func (t *Toaster) Tick(dt float32) bool {
+	kept := t.toasts[:0]
+	for _, item := range t.toasts {
+		item.left -= dt
+		if item.left <= 0 {
+			item.fade.SetVisible(false)
+		}
+		item.fade.Tick(dt)
+		if item.left > 0 || item.fade.Value > 0 {
+			kept = append(kept, item)
+		}
+	}
+	clear(t.toasts[len(kept):])
+	t.toasts = kept
+	return len(t.toasts) > 0
+}
+
+// Update dismisses the toast clicked on
+func (t *Toaster) Update(event sdl.Event, mx, my float32) bool {
+	if event.Type() != sdl.EventMouseButtonDown {
+		return false
+	}
+	for _, item := range t.toasts {
+		if rect := t.toastRect(item); sdl.PointInRectFloat(sdl.FPoint{X: mx, Y: my}, rect) {
+			item.left = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Toaster) Render(renderer *sdl.Renderer) {
+	margin, lineH := float32(10), fontHeight(t.font)
+	for _, item := range t.toasts {
+		rect := t.toastRect(item)
+		restore := PushOpacity(item.fade.Value)
+		FillRoundedRect(renderer, rect, 6, t.Background)
+		x, y := rect.X+margin, rect.Y+margin
+		DrawText(renderer, t.font, item.title, x, y, t.Color)
+		body := t.Color
+		body.A = 200 // Dimmer than the title
+		for _, line := range item.lines {
+			y += lineH
+			DrawText(renderer, t.font, line, x, y, body)
+		}
+		restore()
+	}
+}
+
+// Helper function returning where a toast is drawn, the newest at the bottom
+// and older ones above it
+func (t *Toaster) toastRect(item *toast) sdl.FRect {
+	margin, lineH := float32(10), fontHeight(t.font)
+	bottom := t.Bounds.Y + t.Bounds.H
+	for i := len(t.toasts) - 1; i >= 0; i-- {
+		other := t.toasts[i]
+		w := MeasureText(t.font, other.title)
+		for _, line := range other.lines {
+			w = max(w, MeasureText(t.font, line))
+		}
+		w += 2 * margin
+		h := lineH*float32(1+len(other.lines)) + 2*margin
+		bottom -= h + margin
+		if other == item {
+			return sdl.FRect{X: t.Bounds.X + t.Bounds.W - w - margin, Y: bottom, W: w, H: h}
+		}
+	}
+	return sdl.FRect{}
+}
+
+func (t *Toaster) GetBounds() sdl.FRect {
+	return t.Bounds
+}
@@ -0,0 +1,193 @@
+// uifile.go
+package ui
+
+// Declarative UI: widget trees described in JSON files instead of code, e.g.
+//
+//	{"type": "column", "x": 10, "y": 10, "spacing": 8, "children": [
+//		{"type": "label", "id": "title", "text": "Settings"},
+//		{"type": "input", "id": "name", "w": 200},
+//		{"type": "button", "text": "Save", "action": "settings.save"}
+//	]}
+//
+// Buttons publish their action on Bus, widgets with an id can be looked up to
+// attach handlers. A UIWatcher reloads the file whenever it changes on disk.
+// More widget types can be added with RegisterWidget.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// How often a UIWatcher checks the file for changes
+const uiWatchInterval = 500 * time.Millisecond
+
+// JSON structure of a widget in a UI file
+type uiNode struct {
+	WidgetSpec
+	Type     string   `json:"type"` // "row", "column" or a registered type ("label", "button", "input"...)
+	ID       string   `json:"id"`
+	Spacing  float32  `json:"spacing"`
+	Children []uiNode `json:"children"`
+}
+
+// UITree is a widget tree built from a UI file
+type UITree struct {
+	Root *Layout
+	IDs  map[string]Widget // Widgets by their "id"
+}
+
+// LoadUI builds the widgets described by a UI file, the root must be a row or column
+func LoadUI(path string, font *ttf.Font, renderer *sdl.Renderer, window *sdl.Window) (*UITree, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var root uiNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if root.Type != "row" && root.Type != "column" {
+		return nil, fmt.Errorf("%s: root must be a row or column, not %q", path, root.Type)
+	}
+
+	tree := &UITree{IDs: make(map[string]Widget)}
+	widget, err := tree.build(root, font, renderer, window)
+	if err != nil {
+		if widget != nil {
+			widget.(*Layout).Destroy()
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	tree.Root = widget.(*Layout)
+	return tree, nil
+}
+
+// Helper function creating the widget for a node and its children. On error
+// the widget built so far is returned too, so it can be destroyed.
+func (t *UITree) build(node uiNode, font *ttf.Font, renderer *sdl.Renderer, window *sdl.Window) (Widget, error) {
+	var widget Widget
+	node.Font, node.Renderer, node.Window = font, renderer, window
+	switch node.Type {
+	case "row", "column":
+		layout := NewLayout(node.X, node.Y, node.Spacing)
+		layout.Vertical = node.Type == "column"
+		layout.Width = node.W
+		for _, child := range node.Children {
+			childWidget, err := t.build(child, font, renderer, window)
+			if childWidget != nil {
+				layout.AddWidget(childWidget)
+			}
+			if err != nil {
+				return layout, err
+			}
+		}
+		widget = layout
+	default:
+		factory, ok := widgetFactories[node.Type]
+		if !ok {
+			return nil, fmt.Errorf("unknown widget type %q", node.Type)
+		}
+		var err error
+		if widget, err = factory(node.WidgetSpec); err != nil {
+			return nil, fmt.Errorf("%s: %w", node.Type, err)
+		}
+	}
+	if node.ID != "" {
+		t.IDs[node.ID] = widget
+	}
+	return widget, nil
+}
+
+// Find returns the widget with the given id, or nil
+func (t *UITree) Find(id string) Widget {
+	return t.IDs[id]
+}
+
+func (t *UITree) Destroy() {
+	t.Root.Destroy()
+}
+
+// UIWatcher shows a UI file and rebuilds it live when the file changes, so
+// layouts can be edited without restarting. Add it like any widget, its Tick
+// checks the file.
+type UIWatcher struct {
+	Path     string
+	Tree     *UITree
+	OnReload func(tree *UITree) // After every (re)load: look up widgets and attach handlers here
+	OnError  func(err error)    // A changed file failed to load, the previous tree stays
+	modTime  time.Time
+	checked  time.Time // Last check, wall-clock time so idle frames with dt near 0 still count
+	font     *ttf.Font
+	renderer *sdl.Renderer
+	window   *sdl.Window
+}
+
+// WatchUI loads a UI file and watches it, onReload (may be nil) is called for the first load too
+func WatchUI(path string, font *ttf.Font, renderer *sdl.Renderer, window *sdl.Window, onReload func(tree *UITree)) (*UIWatcher, error) {
+	w := &UIWatcher{Path: path, OnReload: onReload, font: font, renderer: renderer, window: window}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Reload rebuilds the tree from the file, keeping the old one if loading fails
+func (w *UIWatcher) Reload() error {
+	if info, err := os.Stat(w.Path); err == nil {
+		w.modTime = info.ModTime()
+	}
+	tree, err := LoadUI(w.Path, w.font, w.renderer, w.window)
+	if err != nil {
+		return err
+	}
+	if w.Tree != nil {
+		w.Tree.Destroy()
+	}
+	w.Tree = tree
+	if w.OnReload != nil {
+		w.OnReload(tree)
+	}
+	return nil
+}
+
+// Tick checks the file every uiWatchInterval, returns true after a reload
+func (w *UIWatcher) Tick(dt float32) bool {
+	animating := w.Tree.Root.Tick(dt)
+	if time.Since(w.checked) < uiWatchInterval {
+		return animating
+	}
+	w.checked = time.Now()
+
+	info, err := os.Stat(w.Path)
+	if err != nil || info.ModTime().Equal(w.modTime) {
+		return animating // Missing files are usually being saved, try again later
+	}
+	if err := w.Reload(); err != nil {
+		if w.OnError != nil {
+			w.OnError(err)
+		}
+		return animating
+	}
+	return true
+}
+
+func (w *UIWatcher) Update(event sdl.Event, mx, my float32) bool {
+	return w.Tree.Root.Update(event, mx, my)
+}
+
+func (w *UIWatcher) Render(renderer *sdl.Renderer) {
+	w.Tree.Root.Render(renderer)
+}
+
+func (w *UIWatcher) GetBounds() sdl.FRect {
+	return w.Tree.Root.GetBounds()
+}
+
+func (w *UIWatcher) Destroy() {
+	w.Tree.Destroy()
+}
@@ -0,0 +1,145 @@
+// vfs.go
+package ui
+
+// Virtual file system for assets: directories, zip archives and embedded
+// files mounted into one tree. Mounts with a higher priority hide the files
+// of lower ones, so packed game data can be overridden by loose user files.
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// VFS is an fs.FS made of mounted file systems (usable as an AssetManager source)
+type VFS struct {
+	mounts []vfsMount // Highest priority first
+}
+
+// A file system mounted at a directory of the VFS
+type vfsMount struct {
+	fsys     fs.FS
+	prefix   string // "" for the root, otherwise "dir/sub"
+	priority int
+	closer   io.Closer // Archives opened by the VFS
+}
+
+func NewVFS() *VFS {
+	return &VFS{}
+}
+
+// Mount adds fsys at prefix ("" or "." for the root). Among mounts with the
+// same priority the one mounted last wins.
+func (v *VFS) Mount(fsys fs.FS, prefix string, priority int) {
+	v.mount(vfsMount{fsys: fsys, prefix: cleanPrefix(prefix), priority: priority})
+}
+
+// MountDir mounts a directory of the disk, it may not exist (yet)
+func (v *VFS) MountDir(dir, prefix string, priority int) {
+	v.Mount(os.DirFS(dir), prefix, priority)
+}
+
+// MountZip mounts the contents of a zip archive, kept open until Close
+func (v *VFS) MountZip(file, prefix string, priority int) error {
+	archive, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	v.mount(vfsMount{fsys: archive, prefix: cleanPrefix(prefix), priority: priority, closer: archive})
+	return nil
+}
+
+// Helper function inserting a mount in priority order
+func (v *VFS) mount(m vfsMount) {
+	index := sort.Search(len(v.mounts), func(i int) bool {
+		return v.mounts[i].priority <= m.priority
+	})
+	v.mounts = append(v.mounts[:index], append([]vfsMount{m}, v.mounts[index:]...)...)
+}
+
+// Helper function normalizing a mount prefix
+func cleanPrefix(prefix string) string {
+	prefix = path.Clean("/" + prefix)[1:]
+	return prefix
+}
+
+// Helper function returning the name inside a mount, false if the mount doesn't cover name
+func (m *vfsMount) relative(name string) (string, bool) {
+	switch {
+	case m.prefix == "":
+		return name, true
+	case name == m.prefix:
+		return ".", true
+	case strings.HasPrefix(name, m.prefix+"/"):
+		return name[len(m.prefix)+1:], true
+	}
+	return "", false
+}
+
+// Open opens the named file from the highest priority mount holding it
+func (v *VFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	for i := range v.mounts {
+		inner, ok := v.mounts[i].relative(name)
+		if !ok {
+			continue
+		}
+		file, err := v.mounts[i].fsys.Open(inner)
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return file, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir lists a directory merged over all mounts (implements fs.ReadDirFS),
+// entries of higher priority mounts hide same-named lower ones
+func (v *VFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	found := false
+	for i := range v.mounts {
+		inner, ok := v.mounts[i].relative(name)
+		if !ok {
+			continue
+		}
+		list, err := fs.ReadDir(v.mounts[i].fsys, inner)
+		if err != nil {
+			continue
+		}
+		found = true
+		for _, entry := range list {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// Close closes the archives mounted with MountZip
+func (v *VFS) Close() error {
+	var errs []error
+	for _, m := range v.mounts {
+		if m.closer != nil {
+			errs = append(errs, m.closer.Close())
+		}
+	}
+	v.mounts = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,744 @@
+// widgets.go
+
+// Package ui provides widgets, layout, text and drawing helpers on top of the
+// SDL3 renderer.
+package ui
+
+// Core widgets: the Widget interface, buttons, labels and the row layout
+// that arranges them.
+
+import (
+	"unicode/utf8"
+
+	"github.com/jupiterrider/purego-sdl3/sdl"
+	"github.com/jupiterrider/purego-sdl3/ttf"
+)
+
+// Widget interface for UI elements
+type Widget interface {
+	Update(event sdl.Event, mx, my float32) bool // Returns true if event was handled
+	Render(renderer *sdl.Renderer)
+	GetBounds() sdl.FRect
+}
+
+// Positioned is implemented by widgets a Layout can move, SetPosition places
+// their top-left corner
+type Positioned interface {
+	SetPosition(x, y float32)
+}
+
+// Animated is implemented by widgets that change over time.
+// Tick advances them by dt seconds and returns true while they still need frames.
+type Animated interface {
+	Tick(dt float32) bool
+}
+
+// Button widget
+type Button struct {
+	Bounds        sdl.FRect
+	Text          string // "&" marks the mnemonic letter, see parseMnemonic
+	Texture       *sdl.Texture
+	OnClick       func()
+	OnDoubleClick func()             // Second click in quick succession (OnClick runs for both)
+	OnLongPress   func(x, y float32) // Held down (finger or mouse) without moving, see GestureRecognizer
+	Action        string             // Published on Bus when clicked, e.g. "counter.increment"
+	IsPressed     bool
+	Hovered       bool               // Mouse is over the button
+	Style         ttf.FontStyleFlags // Bold, italic, underline, strikethrough
+	Skin          *NinePatch         // Background skin (nil draws a plain rounded rect)
+	PressedSkin   *NinePatch         // Skin while pressed (nil uses Skin)
+	HoverSkin     *NinePatch         // Skin under the mouse (nil uses Skin)
+	Opacity       float32            // 0 (invisible) to 1 (opaque)
+	Disabled      bool               // Ignores clicks and is drawn dimmed
+	Focused       bool               // Enter or Space clicks it (see FocusManager)
+	font          *ttf.Font
+	renderer      *sdl.Renderer
+	autoW         bool // Width follows the text size
+	autoH         bool // Height follows the text size
+	clicks        ClickCounter
+	mnemonic      rune    // Alt with this letter clicks the button
+	underlineX    float32 // Mnemonic underline, relative to the text
+	underlineW    float32
+	Dirty
+}
+
+func NewButton(x, y, w, h float32, text string, font *ttf.Font, renderer *sdl.Renderer, onClick func()) *Button {
+	// Auto-size button based on text if width/height are 0
+	button := &Button{
+		Bounds:   sdl.FRect{X: x, Y: y, W: w, H: h},
+		Text:     text,
+		OnClick:  onClick,
+		font:     font,
+		renderer: renderer,
+		autoW:    w <= 0,
+		autoH:    h <= 0,
+		Opacity:  1,
+	}
+	button.renderText()
+	textureTracker.TrackText(button, button.renderText)
+	return button
+}
+
+// Create button text texture
+func (b *Button) renderText() {
+	var surface *sdl.Surface
+	text, mnemonic, index := parseMnemonic(b.Text)
+	b.mnemonic = mnemonic
+	withFontStyle(b.font, b.Style, func(face *ttf.Font) {
+		surface = renderTextSurface(face, text, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		if mnemonic != 0 {
+			_, size := utf8.DecodeRuneInString(text[index:])
+			b.underlineX = MeasureText(face, text[:index])
+			b.underlineW = MeasureText(face, text[:index+size]) - b.underlineX
+		}
+	})
+	if surface == nil {
+		panic(sdl.GetError())
+	}
+	defer sdl.DestroySurface(surface)
+
+	texture := sdl.CreateTextureFromSurface(b.renderer, surface)
+	if texture == nil {
+		panic(sdl.GetError())
+	}
+
+	if b.Texture != nil {
+		sdl.DestroyTexture(b.Texture)
+	}
+	b.Texture = texture
+
+	// Auto-sized buttons follow the text (e.g. after a display scale change)
+	textW, textH := textureLogicalSize(texture)
+	if b.autoW {
+		b.Bounds.W = textW + 20 // Add padding
+	}
+	if b.autoH {
+		b.Bounds.H = textH + 16 // Add padding
+	}
+	b.MarkDirty()
+}
+
+// SetText changes the caption (auto-sized buttons follow the new text)
+func (b *Button) SetText(text string) {
+	b.Text = text
+	b.renderText()
+}
+
+// SetStyle re-renders the button text with style flags (bounds are kept)
+func (b *Button) SetStyle(style ttf.FontStyleFlags) {
+	b.Style = style
+	b.renderText()
+}
+
+// SetFont re-renders the button text with a different font (bounds are kept)
+func (b *Button) SetFont(font *ttf.Font) {
+	b.font = font
+	b.renderText()
+}
+
+// SetOpacity changes the button opacity (0 to 1)
+func (b *Button) SetOpacity(opacity float32) {
+	b.Opacity = opacity
+	b.MarkDirty()
+}
+
+// SetDisabled enables or disables the button
+func (b *Button) SetDisabled(disabled bool) {
+	b.Disabled = disabled
+	b.IsPressed = false
+	b.Hovered = false
+	b.MarkDirty()
+}
+
+// Focus lets Enter and Space click the button
+func (b *Button) Focus() {
+	b.Focused = true
+	b.MarkDirty()
+}
+
+func (b *Button) Blur() {
+	b.Focused = false
+	b.MarkDirty()
+}
+
+func (b *Button) HasFocus() bool {
+	return b.Focused
+}
+
+// Activate clicks the button (the gamepad's A button on a focused button)
+func (b *Button) Activate() {
+	if b.Disabled {
+		return
+	}
+	b.click()
+}
+
+// Helper function running the click handler and publishing the action
+func (b *Button) click() {
+	PlaySound(SoundClick)
+	if b.OnClick != nil {
+		b.OnClick()
+	}
+	if b.Action != "" {
+		Bus.Publish(b.Action, b)
+	}
+}
+
+func (b *Button) Update(event sdl.Event, mx, my float32) bool {
+	if b.Disabled {
+		return false
+	}
+	switch event.Type() {
+	case sdl.EventMouseButtonDown:
+		if mx >= b.Bounds.X && mx <= b.Bounds.X+b.Bounds.W &&
+			my >= b.Bounds.Y && my <= b.Bounds.Y+b.Bounds.H {
+			b.IsPressed = true
+			b.MarkDirty()
+			b.click()
+			if b.clicks.Click(event) == 2 && b.OnDoubleClick != nil {
+				b.OnDoubleClick()
+			}
+			return true
+		}
+	case sdl.EventMouseButtonUp:
+		if b.IsPressed {
+			b.IsPressed = false
+			b.MarkDirty()
+		}
+	case sdl.EventMouseMotion, sdl.EventWindowMouseLeave:
+		// Returns true when the highlight changed, so the caller redraws
+		hovered := event.Type() == sdl.EventMouseMotion &&
+			sdl.PointInRectFloat(sdl.FPoint{X: mx, Y: my}, b.Bounds)
+		if hovered != b.Hovered {
+			b.Hovered = hovered
+			b.MarkDirty()
+			return true
+		}
+	case sdl.EventKeyDown:
+		if !b.Focused || event.Key().Repeat {
+			return false
+		}
+		switch event.Key().Scancode {
+		case sdl.ScancodeReturn, sdl.ScancodeKpEnter, sdl.ScancodeSpace:
+			b.click()
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Button) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(b.Opacity)()
+	if b.Disabled {
+		defer PushOpacity(0.4)() // Dimmed
+	}
+
+	// Draw button background
+	if skin := b.Skin; skin != nil {
+		if b.IsPressed && b.PressedSkin != nil {
+			skin = b.PressedSkin
+		} else if b.Hovered && !b.IsPressed && b.HoverSkin != nil {
+			skin = b.HoverSkin
+		}
+		skin.Render(renderer, b.Bounds)
+	} else {
+		background := sdl.Color{R: 80, G: 80, B: 80, A: 255}
+		if b.IsPressed {
+			background = sdl.Color{R: 60, G: 60, B: 60, A: 255}
+		} else if b.Hovered {
+			background = sdl.Color{R: 100, G: 100, B: 100, A: 255}
+		}
+		FillRoundedRect(renderer, b.Bounds, 6, background)
+	}
+
+	// Draw button text (centered)
+	textW, textH := textureLogicalSize(b.Texture)
+	textRect := sdl.FRect{
+		X: b.Bounds.X + (b.Bounds.W-textW)/2,
+		Y: b.Bounds.Y + (b.Bounds.H-textH)/2,
+		W: textW,
+		H: textH,
+	}
+	drawTexture(renderer, b.Texture, nil, &textRect)
+	if b.mnemonic != 0 {
+		y := textRect.Y + textRect.H - 2
+		DrawLine(renderer, textRect.X+b.underlineX, y, textRect.X+b.underlineX+b.underlineW, y, 1, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+	}
+}
+
+func (b *Button) GetBounds() sdl.FRect {
+	return b.Bounds
+}
+
+func (b *Button) SetPosition(x, y float32) {
+	b.Bounds.X, b.Bounds.Y = x, y
+}
+
+func (b *Button) Destroy() {
+	textureTracker.Untrack(b)
+	if b.Texture != nil {
+		sdl.DestroyTexture(b.Texture)
+		b.Texture = nil
+	}
+}
+
+// Label widget for displaying text
+type Label struct {
+	Bounds        sdl.FRect
+	Text          string
+	Texture       *sdl.Texture
+	Selectable    bool               // Allow selecting text with the mouse (plain text only)
+	Markup        bool               // Interpret Text as inline markup, e.g. "[b]bold[/b]"
+	Style         ttf.FontStyleFlags // Bold, italic, underline, strikethrough
+	MaxWidth      float32            // Truncate text wider than this (0 = unlimited)
+	Truncate      TruncateMode       // Where to put the ellipsis when truncating
+	Opacity       float32            // 0 (invisible) to 1 (opaque)
+	OnDoubleClick func()             // E.g. open the item a list row shows
+	OnLongPress   func(x, y float32) // E.g. open a context menu, instead of selecting the text
+	DragData      any                // Makes the label draggable (see DragDropManager), the payload of its drags
+	font          *ttf.Font
+	renderer      *sdl.Renderer
+	binding       func() string // Source of Text when bound (see BindText)
+	clicks        ClickCounter
+
+	// Text actually shown, Text shortened with an ellipsis when it doesn't fit
+	displayText string
+
+	// Selected byte range of displayText (anchor and cursor may be in any order)
+	selectionAnchor int
+	selectionCursor int
+	selecting       bool
+	Dirty
+}
+
+func NewLabel(x, y float32, text string, font *ttf.Font, renderer *sdl.Renderer) *Label {
+	label := &Label{
+		Text:     text,
+		font:     font,
+		renderer: renderer,
+		Opacity:  1,
+	}
+	label.UpdateText(text)
+	textureTracker.TrackText(label, label.renderText)
+	label.Bounds.X = x
+	label.Bounds.Y = y
+	return label
+}
+
+func (l *Label) UpdateText(text string) {
+	l.Text = text
+	l.selectionAnchor = 0
+	l.selectionCursor = 0
+	l.renderText()
+}
+
+// SetStyle re-renders the label with style flags
+func (l *Label) SetStyle(style ttf.FontStyleFlags) {
+	l.Style = style
+	l.renderText()
+}
+
+// SetMarkup switches the label to markup mode and shows the given marked-up text
+func (l *Label) SetMarkup(markup string) {
+	l.Markup = true
+	l.UpdateText(markup)
+}
+
+// Create label text texture (also used to recreate it after a device reset)
+func (l *Label) renderText() {
+	if l.Texture != nil {
+		sdl.DestroyTexture(l.Texture)
+		l.Texture = nil
+	}
+
+	// For now, render as single line - multiline support would require more complex text layout
+	var surface *sdl.Surface
+	if l.Markup {
+		spans := ParseMarkup(l.Text)
+		for i := range spans {
+			spans[i].Style |= l.Style // Label style applies on top of markup
+		}
+		surface = renderSpansSurface(l.font, spans, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+	} else {
+		withFontStyle(l.font, l.Style, func(face *ttf.Font) {
+			l.displayText = truncateText(face, l.Text, l.MaxWidth, l.Truncate)
+			surface = renderTextSurface(face, l.displayText, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		})
+	}
+	if surface != nil {
+		l.Texture = sdl.CreateTextureFromSurface(l.renderer, surface)
+		l.Bounds.W, l.Bounds.H = textureLogicalSize(l.Texture)
+		sdl.DestroySurface(surface)
+	}
+	l.MarkDirty()
+}
+
+// SetMaxWidth constrains the label width, truncating the text as needed
+func (l *Label) SetMaxWidth(maxWidth float32) {
+	if maxWidth == l.MaxWidth {
+		return
+	}
+	l.MaxWidth = maxWidth
+	l.selectionAnchor = 0
+	l.selectionCursor = 0
+	l.renderText()
+}
+
+// SetOpacity changes the label opacity (0 to 1)
+func (l *Label) SetOpacity(opacity float32) {
+	l.Opacity = opacity
+	l.MarkDirty()
+}
+
+// SetFont re-renders the label text with a different font
+func (l *Label) SetFont(font *ttf.Font) {
+	l.font = font
+	l.UpdateText(l.Text)
+}
+
+// SelectedText returns the text selected with the mouse (empty if none)
+func (l *Label) SelectedText() string {
+	start, end := l.selectionAnchor, l.selectionCursor
+	if start > end {
+		start, end = end, start
+	}
+	return l.displayText[start:end]
+}
+
+// Copy puts the selected text on the clipboard
+func (l *Label) Copy() {
+	if text := l.SelectedText(); text != "" {
+		SetClipboardText(text)
+	}
+}
+
+// Text offset under window x position, measured with the label style
+func (l *Label) offsetAt(x float32) int {
+	offset := 0
+	withFontStyle(l.font, l.Style, func(face *ttf.Font) {
+		offset = textOffsetAt(face, l.displayText, x-l.Bounds.X)
+	})
+	return offset
+}
+
+func (l *Label) Update(event sdl.Event, mx, my float32) bool {
+	if l.OnDoubleClick != nil && event.Type() == sdl.EventMouseButtonDown &&
+		sdl.PointInRectFloat(sdl.FPoint{X: mx, Y: my}, l.Bounds) && l.clicks.Click(event) == 2 {
+		l.OnDoubleClick()
+		return true
+	}
+	if !l.Selectable || l.Markup {
+		return false // Plain labels don't handle events
+	}
+
+	switch event.Type() {
+	case sdl.EventMouseButtonDown:
+		if mx >= l.Bounds.X && mx <= l.Bounds.X+l.Bounds.W &&
+			my >= l.Bounds.Y && my <= l.Bounds.Y+l.Bounds.H {
+			offset := l.offsetAt(mx)
+			l.selectionAnchor = offset
+			l.selectionCursor = offset
+			l.selecting = true
+			l.MarkDirty()
+			return true
+		}
+		// Clicking elsewhere clears the selection
+		if l.selectionAnchor != l.selectionCursor {
+			l.selectionAnchor = l.selectionCursor
+			l.MarkDirty()
+		}
+	case sdl.EventMouseMotion:
+		if l.selecting {
+			l.selectionCursor = l.offsetAt(mx)
+			l.MarkDirty()
+			return true
+		}
+	case sdl.EventMouseButtonUp:
+		if l.selecting {
+			l.selecting = false
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Label) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(l.Opacity)()
+	withFontStyle(l.font, l.Style, func(face *ttf.Font) {
+		drawSelection(renderer, face, l.displayText, l.selectionAnchor, l.selectionCursor, l.Bounds.X, l.Bounds.Y)
+	})
+	if l.Texture != nil {
+		drawTexture(renderer, l.Texture, nil, &l.Bounds)
+	}
+}
+
+func (l *Label) GetBounds() sdl.FRect {
+	return l.Bounds
+}
+
+func (l *Label) SetPosition(x, y float32) {
+	l.Bounds.X, l.Bounds.Y = x, y
+}
+
+func (l *Label) Destroy() {
+	textureTracker.Untrack(l)
+	if l.Texture != nil {
+		sdl.DestroyTexture(l.Texture)
+		l.Texture = nil
+	}
+}
+
+// Layout system
+type Layout struct {
+	X, Y        float32
+	Width       float32 // Available width, labels past it are truncated (0 = unlimited)
+	Spacing     float32
+	Vertical    bool // Stack widgets top to bottom instead of left to right
+	Widgets     []Widget
+	CacheRender bool          // Draw widgets into a texture and reuse it until one changes
+	Opacity     float32       // Applies to all widgets, multiplied with their own opacity
+	BlendMode   sdl.BlendMode // How the widgets (or the cached texture) combine with what is behind
+	MinWidth    float32       // Smallest area covered, e.g. so an empty drop target can still be hit
+	MinHeight   float32
+	AcceptDrop  func(payload any) bool       // Which dragged payloads OnDrop takes (nil: all)
+	OnDrop      func(payload any, index int) // Makes the layout a drop target, index is where in Widgets it was dropped
+	cache       RenderCache
+}
+
+func NewLayout(x, y, spacing float32) *Layout {
+	return &Layout{X: x, Y: y, Spacing: spacing, Widgets: make([]Widget, 0), Opacity: 1, BlendMode: sdl.BlendModeBlend}
+}
+
+func (layout *Layout) AddWidget(widget Widget) {
+	layout.place(widget, len(layout.Widgets))
+	layout.Widgets = append(layout.Widgets, widget)
+}
+
+// InsertWidget adds widget at index (clamped) and moves the widgets after it
+func (layout *Layout) InsertWidget(index int, widget Widget) {
+	index = max(0, min(index, len(layout.Widgets)))
+	layout.Widgets = append(layout.Widgets[:index], append([]Widget{widget}, layout.Widgets[index:]...)...)
+	layout.Relayout()
+	layout.cache.Invalidate()
+}
+
+// RemoveWidget takes widget out of the layout without destroying it, returns false if it wasn't there
+func (layout *Layout) RemoveWidget(widget Widget) bool {
+	for i, w := range layout.Widgets {
+		if w == widget {
+			layout.Widgets = append(layout.Widgets[:i], layout.Widgets[i+1:]...)
+			layout.Relayout()
+			layout.cache.Invalidate()
+			return true
+		}
+	}
+	return false
+}
+
+// IndexAt returns where a widget dropped at x, y goes in Widgets: before the
+// first widget whose center is past the point
+func (layout *Layout) IndexAt(x, y float32) int {
+	for i, widget := range layout.Widgets {
+		bounds := widget.GetBounds()
+		if layout.Vertical && y < bounds.Y+bounds.H/2 || !layout.Vertical && x < bounds.X+bounds.W/2 {
+			return i
+		}
+	}
+	return len(layout.Widgets)
+}
+
+// Relayout repositions all widgets, e.g. after their sizes changed
+func (layout *Layout) Relayout() {
+	for i, widget := range layout.Widgets {
+		layout.place(widget, i)
+	}
+}
+
+// Positions widget as the index-th item of the layout
+func (layout *Layout) place(widget Widget, index int) {
+	bounds := widget.GetBounds()
+
+	// Position widget based on layout
+	bounds.X = layout.X
+	bounds.Y = layout.Y
+	if index > 0 {
+		// Position relative to previous widget
+		lastBounds := layout.Widgets[index-1].GetBounds()
+		if layout.Vertical {
+			bounds.Y = lastBounds.Y + lastBounds.H + layout.Spacing
+		} else {
+			bounds.X = lastBounds.X + lastBounds.W + layout.Spacing
+		}
+	}
+
+	// Labels shrink to the remaining layout width
+	if lbl, ok := widget.(*Label); ok && layout.Width > 0 {
+		lbl.SetMaxWidth(max(layout.X+layout.Width-bounds.X, 1))
+	}
+	if positioned, ok := widget.(Positioned); ok {
+		positioned.SetPosition(bounds.X, bounds.Y)
+	}
+}
+
+func (layout *Layout) Update(event sdl.Event, mx, my float32) bool {
+	switch event.Type() {
+	case sdl.EventMouseWheel:
+		return ScrollAt(layout.Widgets, event) // Only the widget under the mouse scrolls
+	case sdl.EventMouseMotion, sdl.EventWindowMouseLeave:
+		// Every widget sees the mouse move, e.g. to end its hover state
+		handled := false
+		for _, widget := range layout.Widgets {
+			if widget.Update(event, mx, my) {
+				handled = true
+			}
+		}
+		return handled
+	}
+	for _, widget := range layout.Widgets {
+		if widget.Update(event, mx, my) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBounds returns the area covered by the widgets, so layouts can be nested
+func (layout *Layout) GetBounds() sdl.FRect {
+	bounds := sdl.FRect{X: layout.X, Y: layout.Y}
+	if len(layout.Widgets) > 0 {
+		bounds = widgetsBounds(layout.Widgets)
+	}
+	bounds.W = max(bounds.W, layout.MinWidth)
+	bounds.H = max(bounds.H, layout.MinHeight)
+	return bounds
+}
+
+// SetPosition moves the layout and places its widgets again, so layouts can be nested
+func (layout *Layout) SetPosition(x, y float32) {
+	layout.X, layout.Y = x, y
+	layout.Relayout()
+}
+
+// takeDirty lets nested layouts report changes of their widgets to cached parents
+func (layout *Layout) takeDirty() bool {
+	dirty := false
+	for _, widget := range layout.Widgets {
+		if reporter, ok := widget.(dirtyReporter); ok && reporter.takeDirty() {
+			dirty = true
+		}
+	}
+	if dirty && layout.CacheRender {
+		layout.cache.Invalidate() // The flags were taken before the own cache saw them
+	}
+	return dirty
+}
+
+// Tick advances animated widgets, returns true while any of them needs more frames
+func (layout *Layout) Tick(dt float32) bool {
+	animating := false
+	for _, widget := range layout.Widgets {
+		if animated, ok := widget.(Animated); ok && animated.Tick(dt) {
+			animating = true
+		}
+	}
+	return animating
+}
+
+func (layout *Layout) Render(renderer *sdl.Renderer) {
+	defer PushOpacity(layout.Opacity)()
+	defer PushBlendMode(layout.BlendMode)()
+	BeginBatch(renderer) // Fills, text and images of the widgets share draw calls
+	defer EndBatch()
+	if layout.CacheRender {
+		layout.cache.Render(renderer, layout.Widgets)
+		return
+	}
+	for _, widget := range layout.Widgets {
+		widget.Render(renderer)
+	}
+}
+
+func (layout *Layout) Destroy() {
+	layout.cache.Destroy()
+	for _, widget := range layout.Widgets {
+		if d, ok := widget.(interface{ Destroy() }); ok {
+			d.Destroy()
+		}
+	}
+}
+
+// WrapText wraps text to fit within a given width
+func WrapText(text string, font *ttf.Font, maxWidth float32) []string {
+	// First split by explicit newlines
+	paragraphs := []string{}
+	currentParagraph := ""
+
+	for _, char := range text {
+		if char == '\n' {
+			if currentParagraph != "" {
+				paragraphs = append(paragraphs, currentParagraph)
+				currentParagraph = ""
+			}
+		} else {
+			currentParagraph += string(char)
+		}
+	}
+	if currentParagraph != "" {
+		paragraphs = append(paragraphs, currentParagraph)
+	}
+
+	// If no explicit newlines, treat the whole text as one paragraph
+	if len(paragraphs) == 0 && text != "" {
+		paragraphs = append(paragraphs, text)
+	}
+
+	// Now wrap each paragraph
+	allLines := []string{}
+	for _, paragraph := range paragraphs {
+		// Split paragraph into words
+		words := []string{}
+		currentWord := ""
+
+		for _, char := range paragraph {
+			if char == ' ' {
+				if currentWord != "" {
+					words = append(words, currentWord)
+					currentWord = ""
+				}
+			} else {
+				currentWord += string(char)
+			}
+		}
+		if currentWord != "" {
+			words = append(words, currentWord)
+		}
+
+		// Wrap words in this paragraph
+		currentLine := ""
+		for _, word := range words {
+			testLine := currentLine
+			if testLine != "" {
+				testLine += " "
+			}
+			testLine += word
+
+			// Measure text width (logical units)
+			textW := MeasureText(font, testLine)
+			if textW <= maxWidth {
+				currentLine = testLine
+			} else {
+				// Word doesn't fit, start new line
+				if currentLine != "" {
+					allLines = append(allLines, currentLine)
+				}
+				currentLine = word
+			}
+		}
+
+		if currentLine != "" {
+			allLines = append(allLines, currentLine)
+		}
+	}
+
+	return allLines
+}